@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/changelog"
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultChangelogFile        = "CHANGELOG.md"
+	defaultChangelogCommitLimit = 500
+)
+
+type changelogOptions struct {
+	file   string
+	date   string
+	polish bool
+	rng    tagRangeOptions
+}
+
+func newChangelogCmd() *cobra.Command {
+	opts := changelogOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "changelog <version>",
+		Short: "Add a release section to CHANGELOG.md from Conventional Commits",
+		Long: `Group the Conventional Commit subjects since the previous tag into Keep a
+Changelog sections (Breaking Changes, Added, Changed, Fixed) and insert them as
+a new <version> section into CHANGELOG.md, leaving existing entries untouched.
+
+Commits whose type is not user facing (docs, chore, ci, test, ...) are skipped.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChangelog(cmd, args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", defaultChangelogFile, "changelog file to update")
+	addTagRangeFlags(cmd, &opts.rng)
+	cmd.Flags().StringVar(&opts.date, "date", "", "release date (default: today, YYYY-MM-DD)")
+	cmd.Flags().BoolVar(&opts.polish, "polish", false, "polish the wording of the entries with AI")
+	return cmd
+}
+
+func runChangelog(cmd *cobra.Command, version string, opts changelogOptions) error {
+	var cfg *config.Config
+	if opts.polish {
+		var err error
+		cfg, err = config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+	}
+
+	gitClient, err := mustOpenRepo()
+	if err != nil {
+		return err
+	}
+
+	section, err := buildChangelogSection(gitClient, cfg, version, opts)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "\nChangelog section:\n%s\n", section)
+
+	if dryRun {
+		fmt.Fprintf(out, "\nDry run mode - %s was not modified\n", opts.file)
+		return nil
+	}
+
+	if err := writeChangelog(opts.file, section); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nUpdated %s\n", opts.file)
+	return nil
+}
+
+func buildChangelogSection(gitClient *git.Git, cfg *config.Config, version string, opts changelogOptions) (string, error) {
+	base, ok, to, err := opts.rng.resolve(gitClient)
+	if err != nil {
+		return "", err
+	}
+	rangeSpec := opts.rng.rangeSpec(base, ok, to)
+
+	messages, truncated, err := gitClient.CommitMessages(rangeSpec, defaultChangelogCommitLimit, opts.rng.paths...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit messages: %w", err)
+	}
+	if truncated {
+		fmt.Fprintf(progress, "Warning: only the newest %d commits of %s are included in the changelog\n", defaultChangelogCommitLimit, rangeSpec)
+	}
+
+	date := strings.TrimSpace(opts.date)
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+
+	release := changelog.NewRelease(version, date, messages)
+	if release.IsEmpty() {
+		return "", fmt.Errorf("no user-facing Conventional Commits found in %s", rangeSpec)
+	}
+
+	section := release.Markdown()
+	if opts.polish {
+		section, err = polishChangelogSection(cfg, section, release)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return section, nil
+}
+
+func polishChangelogSection(cfg *config.Config, section string, release *changelog.Release) (string, error) {
+	provider, err := model.NewProvider(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create provider: %w", err)
+	}
+	provider.SetTemplate(prompt.NewChangelogTemplate())
+
+	fmt.Fprintf(progress, "Polishing changelog using %s with model %s...\n", provider.Name(), resolveModelName(cfg))
+
+	polished, err := generateText(context.Background(), provider, section)
+	if err != nil {
+		return "", fmt.Errorf("failed to polish changelog: %w", err)
+	}
+
+	polished = prompt.CleanMarkdown(polished)
+	if err := release.CheckRewrite(polished); err != nil {
+		return "", fmt.Errorf("polished changelog is invalid: %w", err)
+	}
+	return polished + "\n", nil
+}
+
+func writeChangelog(path, section string) error {
+	existing, err := os.ReadFile(path) // #nosec G304 -- Path comes from the user's flags.
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated, err := changelog.Insert(string(existing), section)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil { // #nosec G306 -- CHANGELOG.md is a tracked project file.
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+// reviewFirst makes the default command review the changes before
+// generating the commit message.
+var reviewFirst bool
+
+// reviewSettings are the review config after applying the flags.
+type reviewSettings struct {
+	failOn prompt.ReviewSeverity
+	// output is "table" or "line".
+	output string
+}
+
+func newReviewCmd() *cobra.Command {
+	var failOn, output string
+
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Review the staged changes with AI before committing",
+		Long: `Send the staged diff to the provider with a reviewer prompt and print the
+findings (file, line, severity, message) as a table or, with --output line, as
+"file:line: severity: message" lines for editors and CI annotations.
+
+The command exits with code 5 if a finding is at or above --fail-on.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReview(cmd, failOn, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&failOn, "fail-on", "", "lowest severity that fails the review: info, warning, error or off (overrides review.fail_on)")
+	cmd.Flags().StringVar(&output, "output", "", "output format: table or line (overrides review.output)")
+	return cmd
+}
+
+func runReview(cmd *cobra.Command, failOn, output string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	settings, err := resolveReviewSettings(cfg, failOn, output)
+	if err != nil {
+		return err
+	}
+
+	gitClient, err := mustOpenRepo()
+	if err != nil {
+		return err
+	}
+	language, err := promptLanguage(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+
+	diff, err := gitClient.GetDiff()
+	if err != nil {
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	provider, err := model.NewProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	return reviewChanges(context.Background(), cfg, provider, language, diff, settings, cmd.OutOrStdout())
+}
+
+// resolveReviewSettings applies the --fail-on and --output flags to the
+// review config.
+func resolveReviewSettings(cfg *config.Config, failOn, output string) (reviewSettings, error) {
+	if failOn == "" {
+		failOn = cfg.Review.FailOn
+	}
+	if output == "" {
+		output = cfg.Review.Output
+	}
+
+	threshold := prompt.ReviewError
+	if strings.TrimSpace(failOn) != "" {
+		var err error
+		if threshold, err = prompt.ParseReviewSeverity(failOn); err != nil {
+			return reviewSettings{}, err
+		}
+	}
+
+	output = strings.ToLower(strings.TrimSpace(output))
+	switch output {
+	case "":
+		output = "table"
+	case "table", "line":
+	default:
+		return reviewSettings{}, fmt.Errorf("invalid review output %q (expected table or line)", output)
+	}
+	return reviewSettings{failOn: threshold, output: output}, nil
+}
+
+// reviewChanges asks the provider to review diff, prints the findings to out
+// and fails with exitReviewFailed if any reach the threshold. The provider's
+// template is left set to the review template.
+func reviewChanges(ctx context.Context, cfg *config.Config, provider model.Provider, language prompt.Language, diff string, settings reviewSettings, out io.Writer) error {
+	template := prompt.NewReviewTemplate()
+	template.SetLanguage(language)
+	provider.SetTemplate(template)
+
+	fmt.Fprintf(progress, "Reviewing changes using %s with model %s...\n", provider.Name(), resolveModelName(cfg))
+
+	var response string
+	var err error
+	if structured, ok := provider.(model.StructuredProvider); ok {
+		response, err = structured.GenerateJSON(ctx, diff, prompt.ReviewSchema())
+	} else {
+		response, err = generateText(ctx, provider, diff)
+	}
+	if err != nil {
+		return withExitCode(exitProviderError, fmt.Errorf("failed to review changes: %w", err))
+	}
+
+	findings, err := prompt.ParseReviewFindings(response)
+	if err != nil {
+		return fmt.Errorf("failed to parse review: %w", err)
+	}
+	printFindings(out, findings, settings.output)
+
+	if failing := prompt.FailingFindings(findings, settings.failOn); len(failing) > 0 {
+		return withExitCode(exitReviewFailed, fmt.Errorf("review found %d finding(s) at or above %s", len(failing), settings.failOn))
+	}
+	return nil
+}
+
+func printFindings(out io.Writer, findings []prompt.Finding, output string) {
+	if output == "line" {
+		for _, f := range findings {
+			fmt.Fprintln(out, f)
+		}
+		return
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "\nNo findings.")
+		return
+	}
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SEVERITY\tLOCATION\tMESSAGE")
+	for _, f := range findings {
+		location := f.File
+		if location == "" {
+			location = "-"
+		} else if f.Line > 0 {
+			location = fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Severity, location, f.Message)
+	}
+	w.Flush()
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// historyExampleSampleFactor controls how many commits are read per wanted
+// example, leaving room for ill-formed messages that are filtered out.
+const historyExampleSampleFactor = 4
+
+// historyExamples samples well-formed commit messages in format from the
+// history, preferring commits that touched the staged files.
+func historyExamples(gitClient *git.Git, files []string, count int, format prompt.Format) []string {
+	if count <= 0 {
+		return nil
+	}
+
+	sample := count * historyExampleSampleFactor
+	var preferred []string
+	if len(files) > 0 {
+		preferred, _ = gitClient.RecentCommitMessages(sample, files...)
+	}
+	fallback, err := gitClient.RecentCommitMessages(sample)
+	if err != nil {
+		// No history yet (e.g. the first commit).
+		return prompt.SelectExamples(preferred, nil, count, format)
+	}
+	return prompt.SelectExamples(preferred, fallback, count, format)
+}
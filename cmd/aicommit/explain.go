@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultExplainCommitLimit     = 100
+	defaultExplainDiffStatMaxLen  = 4000
+	defaultExplainCommitBodyLimit = 8000
+)
+
+func newExplainCmd() *cobra.Command {
+	var plain bool
+
+	cmd := &cobra.Command{
+		Use:   "explain <rev|range>",
+		Short: "Explain what a commit or range of commits changed and why with AI",
+		Long: `Explain a commit (e.g. HEAD or abc123) or a range (e.g. v1.2.0..v1.3.0 or
+main...feature) in plain language. The commit messages and diff are sent to
+the provider with the same size limit as for commit messages.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExplain(cmd, args[0], plain)
+		},
+	}
+
+	cmd.Flags().BoolVar(&plain, "plain", false, "print plain text instead of Markdown")
+	return cmd
+}
+
+func runExplain(cmd *cobra.Command, rev string, plain bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	gitClient, err := mustOpenRepo()
+	if err != nil {
+		return err
+	}
+	language, err := promptLanguage(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+
+	infoBlock, err := buildExplainContext(gitClient, rev)
+	if err != nil {
+		return err
+	}
+
+	provider, err := model.NewProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	template := prompt.NewExplainTemplate(!plain)
+	template.SetLanguage(language)
+	provider.SetTemplate(template)
+
+	fmt.Fprintf(progress, "Explaining %s using %s with model %s...\n", rev, provider.Name(), resolveModelName(cfg))
+
+	explanation, err := generateText(context.Background(), provider, infoBlock)
+	if err != nil {
+		return withExitCode(exitProviderError, fmt.Errorf("failed to explain %s: %w", rev, err))
+	}
+	explanation = prompt.CleanMarkdown(explanation)
+	if strings.TrimSpace(explanation) == "" {
+		return fmt.Errorf("generated explanation is empty")
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", explanation)
+	return nil
+}
+
+// buildExplainContext gathers git show output for a single commit, or the
+// commit log, diffstat and diff for a range such as a..b or a...b.
+func buildExplainContext(gitClient *git.Git, rev string) (string, error) {
+	rev = strings.TrimSpace(rev)
+	if !strings.Contains(rev, "..") {
+		show, err := gitClient.Show(rev)
+		if err != nil {
+			return "", err
+		}
+		return "Commit:\n" + show, nil
+	}
+
+	commits, truncated, err := gitClient.CommitLog(rev, defaultExplainCommitLimit)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit log: %w", err)
+	}
+	if len(commits) == 0 {
+		return "", fmt.Errorf("no commits in %s", rev)
+	}
+
+	diffStat := formatOrUnavailable(func() (string, error) { return gitClient.DiffStat(rev) }, defaultExplainDiffStatMaxLen)
+	diff, err := gitClient.DiffRange(rev)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Range: %s\n", rev)
+	b.WriteString("\nCommits (newest first):\n")
+	writeTagCommits(&b, commits, defaultExplainCommitBodyLimit)
+	if truncated {
+		fmt.Fprintf(&b, "(only the newest %d commits are listed)\n", defaultExplainCommitLimit)
+	}
+
+	b.WriteString("\nDiffstat:\n")
+	b.WriteString(diffStat)
+	b.WriteString("\n")
+
+	b.WriteString("\nDiff:\n")
+	b.WriteString(diff)
+	b.WriteString("\n")
+	return b.String(), nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var assumeYes bool
+
+// addYesFlags registers --yes and its alias --no-edit, which skip the editor
+// and use the generated message as is.
+func addYesFlags(cmd *cobra.Command, usage string) {
+	cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, usage)
+	cmd.Flags().BoolVar(&assumeYes, "no-edit", false, "same as --yes")
+}
+
+// interactive reports whether aicommit may open an editor or ask questions:
+// not with --yes, --print or --json, and not when stdin is not a terminal,
+// e.g. in CI or when run by a bot.
+func interactive() bool {
+	return !assumeYes && !machineOutput() && stdinIsTerminal()
+}
+
+// noteNonInteractive explains why the editor is skipped when stdin is not a
+// terminal, as that is easy to miss in CI logs.
+func noteNonInteractive() {
+	if !assumeYes && !machineOutput() {
+		fmt.Fprintln(progress, "\nstdin is not a terminal; skipping the editor (as with --yes)")
+	}
+}
+
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
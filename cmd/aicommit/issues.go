@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/pkg/issue"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+const (
+	issueModePrompt  = "prompt"
+	issueModeTrailer = "trailer"
+	issueModeSubject = "subject"
+
+	defaultIssueTrailer = "Refs"
+)
+
+// issueRefs are the issue keys found in the current branch name.
+type issueRefs struct {
+	keys      []string
+	mode      string
+	trailer   string
+	format    prompt.Format
+	gitClient *git.Git
+}
+
+// branchIssueRefs extracts the issue keys from the current branch. It returns
+// no keys when the feature is disabled or HEAD is detached.
+func branchIssueRefs(cfg *config.Config, gitClient *git.Git, format prompt.Format) (issueRefs, error) {
+	mode := strings.ToLower(strings.TrimSpace(cfg.Issues.Mode))
+	switch mode {
+	case "":
+		return issueRefs{}, nil
+	case issueModePrompt, issueModeTrailer, issueModeSubject:
+	default:
+		return issueRefs{}, fmt.Errorf("invalid issues.mode %q (expected prompt, trailer or subject)", cfg.Issues.Mode)
+	}
+
+	matcher, err := issue.NewMatcher(cfg.Issues.Patterns)
+	if err != nil {
+		return issueRefs{}, err
+	}
+
+	refs := issueRefs{mode: mode, trailer: strings.TrimSpace(cfg.Issues.Trailer), format: format, gitClient: gitClient}
+	if refs.trailer == "" {
+		refs.trailer = defaultIssueTrailer
+	}
+
+	branch, err := gitClient.CurrentBranch()
+	if err != nil || branch == "HEAD" {
+		return refs, nil
+	}
+	refs.keys = matcher.Keys(branch)
+	return refs, nil
+}
+
+// instruction tells the model how to handle the issue keys.
+func (r issueRefs) instruction() string {
+	if len(r.keys) == 0 {
+		return ""
+	}
+	keys := strings.Join(r.keys, ", ")
+	if r.mode == issueModePrompt {
+		return fmt.Sprintf("The branch belongs to issue %s: reference it in a \"%s: %s\" footer.", keys, r.trailer, r.keys[0])
+	}
+	return fmt.Sprintf("Do not mention issue %s; it is added to the message automatically.", keys)
+}
+
+// apply adds the issue keys as trailers or as a subject prefix.
+func (r issueRefs) apply(message string) (string, error) {
+	if len(r.keys) == 0 {
+		return message, nil
+	}
+
+	switch r.mode {
+	case issueModeTrailer:
+		trailers := make([]git.Trailer, 0, len(r.keys))
+		for _, k := range r.keys {
+			trailers = append(trailers, git.Trailer{Key: r.trailer, Value: k})
+		}
+		return r.gitClient.AddTrailers(message, trailers)
+	case issueModeSubject:
+		// Like scopes, the keys are only added to a valid subject; an invalid
+		// message gets them once it is repaired.
+		if _, err := r.format.ParseHeader(firstLine(message)); err != nil {
+			return message, nil
+		}
+		return r.format.PrefixDescription(message, strings.Join(r.keys, " "))
+	}
+	return message, nil
+}
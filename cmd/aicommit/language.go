@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// promptLanguage resolves the language of the generated text: --lang, then
+// the repository's aicommit.language, then the language setting.
+func promptLanguage(cfg *config.Config, gitClient *git.Git) (prompt.Language, error) {
+	value := lang
+	if value == "" {
+		value = gitClient.Language()
+	}
+	if value == "" {
+		value = cfg.Language
+	}
+	language, err := prompt.LookupLanguage(value)
+	if err != nil {
+		return prompt.Language{}, fmt.Errorf("invalid language: %w", err)
+	}
+	return language, nil
+}
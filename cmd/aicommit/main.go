@@ -5,12 +5,10 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strings"
 
 	"github.com/aicommit/aicommit/internal/config"
 	"github.com/aicommit/aicommit/internal/git"
 	"github.com/aicommit/aicommit/internal/model"
-	"github.com/aicommit/aicommit/pkg/editor"
 	"github.com/aicommit/aicommit/pkg/prompt"
 	"github.com/aicommit/aicommit/pkg/validator"
 	"github.com/spf13/cobra"
@@ -20,23 +18,35 @@ var (
 	version = "1.0.0"
 	cfgFile string
 	dryRun  bool
+	sign    bool
+	verbose bool
+	lang    string
+
+	signoff   bool
+	coAuthors []string
 )
 
 func main() {
-	// 示例：你可以在这里自定义prompt
-	// SetChinesePrompt()  // 使用中文prompt
-	// SetDetailedPrompt() // 使用详细prompt
-	// SetMinimalPrompt()  // 使用简洁prompt
-
 	rootCmd := &cobra.Command{
-		Use:   "aicommit",
+		Use:   "aicommit [flags] [--] [<pathspec>...]",
 		Short: "AI-powered git commit message generator",
 		Long:  "aicommit uses AI models to generate meaningful commit messages based on your staged changes",
+		Args:  cobra.ArbitraryArgs,
 		RunE:  run,
 	}
+	rootCmd.Flags().BoolVarP(&stageAll, "all", "a", false, "commit the changes of all tracked files, like git commit -a")
+	rootCmd.Flags().BoolVar(&includeUntracked, "include-untracked", false, "also commit untracked files (within the given paths)")
+	rootCmd.Flags().BoolVar(&reviewFirst, "review", false, "review the changes first and stop if a finding reaches review.fail_on")
+	rootCmd.Flags().BoolVarP(&signoff, "signoff", "s", false, "add a Signed-off-by trailer with your git identity")
+	rootCmd.Flags().StringArrayVar(&coAuthors, "co-author", nil, "add a Co-authored-by trailer (alias from co_authors or \"Name <email>\"; repeatable)")
+	addOutputFlags(rootCmd)
+	addYesFlags(rootCmd, "commit the generated message without opening the editor if it passes validation")
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is $HOME/.config/aicommit/aicommit.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&dryRun, "dry-run", "d", false, "show the generated commit message without committing")
+	rootCmd.PersistentFlags().BoolVarP(&sign, "sign", "S", false, "GPG/SSH-sign created commits and tags (uses user.signingkey and gpg.format)")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "language of the generated text, e.g. zh, ja, de or es (overrides aicommit.language and language)")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "show the diff below the message in the editor (like git commit -v or commit.verbose)")
 
 	versionCmd := &cobra.Command{
 		Use:   "version",
@@ -60,13 +70,21 @@ func main() {
 	configCmd.AddCommand(configInitCmd)
 	rootCmd.AddCommand(versionCmd, configCmd)
 	rootCmd.AddCommand(newTagCmd())
+	rootCmd.AddCommand(newSquashCmd())
+	rootCmd.AddCommand(newPRCmd())
+	rootCmd.AddCommand(newChangelogCmd())
+	rootCmd.AddCommand(newExplainCmd())
+	rootCmd.AddCommand(newReviewCmd())
 
 	if err := rootCmd.Execute(); err != nil {
-		log.Fatal(err)
+		log.Print(err)
+		os.Exit(exitCode(err))
 	}
 }
 
 func run(cmd *cobra.Command, args []string) error {
+	redirectProgress(cmd)
+
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -80,6 +98,11 @@ func run(cmd *cobra.Command, args []string) error {
 	if !gitClient.IsRepository() {
 		return fmt.Errorf("not a git repository")
 	}
+	gitClient.SetSigning(signOptions(cfg))
+	defer gitClient.Close()
+	if err := selectChanges(gitClient, args); err != nil {
+		return err
+	}
 
 	diff, err := gitClient.GetDiff()
 	if err != nil {
@@ -90,44 +113,147 @@ func run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create provider: %w", err)
 	}
-	provider.SetTemplate(prompt.NewDefaultTemplate())
-
-	ctx := context.Background()
+	rules, err := commitRules(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+	language, err := promptLanguage(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+	if reviewFirst {
+		settings, err := resolveReviewSettings(cfg, "", "")
+		if err != nil {
+			return err
+		}
+		if err := reviewChanges(context.Background(), cfg, provider, language, diff, settings, progress); err != nil {
+			return err
+		}
+	}
+	scopes, err := stagedPackageScopes(cfg, gitClient, rules.Format)
+	if err != nil {
+		return err
+	}
+	issues, err := branchIssueRefs(cfg, gitClient, rules.Format)
+	if err != nil {
+		return err
+	}
+	trailers, err := buildCommitTrailers(cfg, gitClient, signoff, coAuthors)
+	if err != nil {
+		return err
+	}
+	files, err := gitClient.StagedFiles()
+	if err != nil {
+		return err
+	}
+	suggestCoAuthors(cfg, gitClient, files, trailers)
 
-	modelName := cfg.Model
-	if cfg.Provider == "custom" && cfg.Custom.Model != "" {
-		modelName = cfg.Custom.Model
+	template := prompt.NewDefaultTemplate()
+	template.SetFormat(rules.Format)
+	template.SetLanguage(language)
+	for _, instruction := range rules.Instructions() {
+		template.AddInstruction(instruction)
 	}
+	template.AddInstruction(scopes.instruction())
+	template.AddInstruction(issues.instruction())
+	template.SetExamples(historyExamples(gitClient, files, cfg.HistoryExamples, rules.Format))
+	provider.SetTemplate(template)
 
-	fmt.Printf("Generating commit message using %s with model %s...\n", provider.Name(), modelName)
+	ctx := context.Background()
 
-	commitMessage, err := provider.GenerateMessage(ctx, diff)
+	fmt.Fprintf(progress, "Generating commit message using %s with model %s...\n", provider.Name(), resolveModelName(cfg))
+
+	commitMessage, err := generateCommitMessage(ctx, cfg, provider, template, rules.Format, diff)
 	if err != nil {
-		return fmt.Errorf("failed to generate commit message: %w", err)
+		return withExitCode(exitProviderError, fmt.Errorf("failed to generate commit message: %w", err))
 	}
 
-	commitMessage = prompt.CleanCommitMessage(commitMessage)
+	validate := newCommitValidator(rules, scopes)
+	repairer := messageRepairer{
+		provider: provider,
+		template: template,
+		input:    diff,
+		attempts: cfg.Rules.RepairAttempts,
+		fix: func(message string) string {
+			return prompt.FixCommitMessage(message, rules)
+		},
+		decorate: func(message string) (string, error) {
+			message, err := scopes.apply(message)
+			if err != nil {
+				return "", err
+			}
+			if message, err = issues.apply(message); err != nil {
+				return "", err
+			}
+			return trailers.apply(message)
+		},
+		validate: validate,
+	}
+	commitMessage, violations, err := repairer.repair(ctx, commitMessage)
+	if err != nil {
+		return err
+	}
 
-	if err := prompt.ValidateCommitMessage(commitMessage); err != nil {
-		return fmt.Errorf("generated commit message is invalid: %w", err)
+	if !machineOutput() {
+		fmt.Printf("\nGenerated commit message:\n%s\n", commitMessage)
 	}
-	if err := prompt.ValidateConventionalCommitMessage(commitMessage); err != nil {
-		return fmt.Errorf("generated commit message is invalid: %w", err)
+	if prompt.HasErrors(violations) {
+		fmt.Fprintln(progress, "\nGenerated commit message is still invalid:")
+		printViolations(violations)
+	} else if len(violations) > 0 {
+		fmt.Fprintln(progress, "\nWarnings:")
+		printViolations(violations)
 	}
 
-	fmt.Printf("\nGenerated commit message:\n%s\n", commitMessage)
+	if machineOutput() {
+		result := newGenerationResult(cfg, provider, commitMessage)
+		if parts, err := rules.Format.ParseMessage(commitMessage); err == nil {
+			result.Parts = &parts
+		}
+		result.Valid = !prompt.HasErrors(violations)
+		if violations != nil {
+			result.Violations = violations
+		}
+		if err := writeResult(result); err != nil {
+			return err
+		}
+		return invalidMessageError(violations)
+	}
 
 	if dryRun {
+		if err := invalidMessageError(violations); err != nil {
+			return err
+		}
 		fmt.Println("\nDry run mode - no commit was made")
 		return nil
 	}
 
-	commitMessage, err = reviewCommitMessage(commitMessage, cfg.Editor)
-	if err != nil {
-		return err
-	}
-	if commitMessage == "" {
-		return nil
+	if interactive() {
+		nameStatus, err := gitClient.StagedNameStatus()
+		if err != nil {
+			return err
+		}
+		review, err := newCommitReview(cfg, gitClient, rules, validate, nameStatus, diff)
+		if err != nil {
+			return err
+		}
+		commitMessage, err = reviewCommitMessage(commitMessage, review)
+		if err != nil {
+			return err
+		}
+		if commitMessage == "" {
+			return nil
+		}
+		// Keep the requested trailers even if they were removed while editing.
+		commitMessage, err = trailers.apply(commitMessage)
+		if err != nil {
+			return err
+		}
+	} else {
+		noteNonInteractive()
+		if err := invalidMessageError(violations); err != nil {
+			return err
+		}
 	}
 
 	if err := gitClient.Commit(commitMessage); err != nil {
@@ -138,33 +264,30 @@ func run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func reviewCommitMessage(commitMessage string, editorCmd string) (string, error) {
-	for attempt := 0; attempt < 3; attempt++ {
-		fmt.Println("\nOpening editor to review/edit commit message...")
-		newCommitMessage, err := editor.Open(commitMessage, editorCmd)
-		if err != nil {
-			return "", fmt.Errorf("failed to open editor: %w", err)
-		}
-
-		commitMessage = strings.TrimSpace(newCommitMessage)
-		if commitMessage == "" {
-			fmt.Println("\nCommit message is empty, aborting commit.")
-			return "", nil
-		}
-
-		if err := prompt.ValidateCommitMessage(commitMessage); err != nil {
-			fmt.Printf("\nCommit message is invalid: %v\n", err)
-			continue
-		}
-		if err := prompt.ValidateConventionalCommitMessage(commitMessage); err != nil {
-			fmt.Printf("\nCommit message is invalid: %v\n", err)
-			continue
-		}
+// invalidMessageError returns an error with exitInvalidMessage if violations
+// contain errors.
+func invalidMessageError(violations []prompt.Violation) error {
+	if err := prompt.ViolationsError(violations); err != nil {
+		return withExitCode(exitInvalidMessage, fmt.Errorf("generated commit message is invalid: %w", err))
+	}
+	return nil
+}
 
-		return commitMessage, nil
+// resolveModelName returns the model name that will be sent to the provider.
+func resolveModelName(cfg *config.Config) string {
+	if cfg.Provider == "custom" && cfg.Custom.Model != "" {
+		return cfg.Custom.Model
 	}
+	return cfg.Model
+}
 
-	return "", fmt.Errorf("commit message is still invalid after multiple edits")
+// signOptions combines the signing config with the --sign flag.
+func signOptions(cfg *config.Config) git.SignOptions {
+	return git.SignOptions{
+		Enabled: sign || cfg.Signing.Enabled,
+		Key:     cfg.Signing.Key,
+		Format:  cfg.Signing.Format,
+	}
 }
 
 func initConfig(cmd *cobra.Command, args []string) error {
@@ -186,7 +309,7 @@ func initConfig(cmd *cobra.Command, args []string) error {
 	defaultConfig := `# aicommit configuration file
 model: claude-3-sonnet-20240229
 provider: claude
-editor: ""  # Optional: nvim, vim, nano, code, etc. If empty, uses $EDITOR or $VISUAL
+editor: ""  # Optional: e.g. "code --wait". If empty, uses $GIT_EDITOR, git's core.editor, $VISUAL or $EDITOR
 
 # API keys - you can also use environment variables:
 # AICOMMIT_CLAUDE_API_KEY, AICOMMIT_OPENAI_API_KEY, AICOMMIT_DEEPSEEK_API_KEY
@@ -201,6 +324,78 @@ custom:
   url: ""      # Full URL to completion endpoint (e.g. http://localhost:11434/v1/chat/completions)
   api_key: ""  # API Key if required
   model: ""    # Model name to pass in request
+
+# Commit and tag signing (same as passing --sign)
+signing:
+  enabled: false
+  key: ""      # Optional: overrides git's user.signingkey
+  format: ""   # Optional: openpgp, ssh or x509; overrides git's gpg.format
+
+# Hosting releases (aicommit tag --release)
+release:
+  forge: ""     # Optional: github, gitlab or gitea (default: detected from the remote URL)
+  base_url: ""  # Optional: API base URL, e.g. https://gitea.example.com/api/v1
+  token: ""     # Or AICOMMIT_RELEASE_TOKEN, GITHUB_TOKEN/GH_TOKEN, GITLAB_TOKEN, GITEA_TOKEN
+
+# Commit message conventions, checked for generated and edited messages
+rules:
+  types: []                   # Allowed types, e.g. [feat, fix, docs]; empty allows any
+  scopes: []                  # Allowed scopes; empty allows any
+  scope_required: false
+  header_max_length: 100      # 0 disables
+  subject_case: ""            # lower, sentence or empty for any
+  subject_no_period: true
+  body_max_line_length: 100   # 0 disables
+  required_trailers: []       # e.g. [Refs]
+  forbidden_trailers: []
+  breaking_consistency: true  # "!" and a BREAKING CHANGE footer must be used together
+  severity: {}                # Per-rule override: error, warning or off, e.g. header-max-length: error
+  repair_attempts: 2          # Follow-up model requests to fix an invalid generated message
+  commitlint: ""              # commitlint config (JSON/YAML) applied on top; empty: auto-detect, off: ignore
+
+# Issue keys from branch names such as feature/PROJ-1234-short-desc
+issues:
+  mode: ""        # prompt (ask the model), trailer (append "Refs: PROJ-1234") or subject (prefix the description)
+  patterns: []    # Regular expressions; the first capture group is the key. Default: [A-Z][A-Z0-9]+-[0-9]+
+  trailer: Refs   # Trailer token used in trailer mode
+
+# Few-shot examples: number of recent well-formed commit messages (preferring
+# ones touching the staged files) shown to the model to match the repo's style
+history_examples: 0
+
+# Ask the provider for JSON fields (type, scope, subject, body, breaking,
+# footers) and assemble the message from them instead of parsing free text
+structured_output: false
+
+# Commit message format: conventional, angular, gitmoji, conventional+gitmoji or
+# free-form. Per repository: git config aicommit.format gitmoji
+format: conventional
+
+# Language of commit descriptions, bodies and tag messages: en, zh, ja, de or es.
+# The commit type keyword (feat, fix, ...) stays in English. Per repository:
+# git config aicommit.language de
+language: en
+
+# Trailers
+signoff: false  # Always add Signed-off-by (same as --signoff)
+co_authors: {}  # Aliases for --co-author, e.g. alice: "Alice Example <alice@example.com>"
+
+# Monorepo packages: scopes for commits and per-package tags (aicommit tag --package)
+monorepo:
+  enforce_scope: false  # Reject commit scopes that do not match the touched packages
+  packages: []
+  # - path: "services/*"     # Directory glob; each match is a package
+  #   scope: ""              # Default: {name}, the last path segment
+  #   tag_prefix: ""         # Default: {dir}/, e.g. services/api/v1.2.0
+
+# Pull request descriptions (aicommit pr)
+pr:
+  template: ""  # Optional: Markdown template for the body, e.g. .github/pull_request_template.md
+
+# Reviews of the staged changes (aicommit review, aicommit --review)
+review:
+  fail_on: error  # Lowest finding severity that exits with code 5: info, warning, error or off
+  output: table   # table, or line for "file:line: severity: message"
 `
 
 	if err := os.WriteFile(configFile, []byte(defaultConfig), 0600); err != nil {
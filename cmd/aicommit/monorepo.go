@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/internal/monorepo"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// packageScopes are the commit scopes of the monorepo packages touched by
+// the staged changes.
+type packageScopes struct {
+	scopes  []string
+	enforce bool
+	format  prompt.Format
+}
+
+func loadMonorepoLayout(cfg *config.Config) (*monorepo.Layout, error) {
+	rules := make([]monorepo.Rule, 0, len(cfg.Monorepo.Packages))
+	for _, p := range cfg.Monorepo.Packages {
+		rules = append(rules, monorepo.Rule{Pattern: p.Path, Scope: p.Scope, TagPrefix: p.TagPrefix})
+	}
+	layout, err := monorepo.New(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid monorepo config: %w", err)
+	}
+	return layout, nil
+}
+
+// stagedPackageScopes derives the scopes from the staged files. It returns
+// an empty result when no monorepo packages are configured or the format has
+// no scopes.
+func stagedPackageScopes(cfg *config.Config, gitClient *git.Git, format prompt.Format) (packageScopes, error) {
+	layout, err := loadMonorepoLayout(cfg)
+	if err != nil {
+		return packageScopes{}, err
+	}
+	if layout.IsEmpty() || !format.HasScope() {
+		return packageScopes{}, nil
+	}
+
+	files, err := gitClient.StagedFiles()
+	if err != nil {
+		return packageScopes{}, err
+	}
+	return packageScopes{scopes: layout.Scopes(files), enforce: cfg.Monorepo.EnforceScope, format: format}, nil
+}
+
+// instruction returns the prompt rule describing the allowed scopes.
+func (p packageScopes) instruction() string {
+	switch len(p.scopes) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("The staged changes belong to the %q package: use exactly the scope (%s).", p.scopes[0], p.scopes[0])
+	default:
+		return fmt.Sprintf("The staged changes touch several packages; use one of these scopes: %s.", strings.Join(p.scopes, ", "))
+	}
+}
+
+// apply sets the scope when the changes belong to a single package and the
+// message uses a different one.
+func (p packageScopes) apply(message string) (string, error) {
+	if len(p.scopes) != 1 {
+		return message, nil
+	}
+	h, err := p.format.ParseHeader(firstLine(message))
+	if err != nil {
+		return message, nil
+	}
+	if h.Scope == p.scopes[0] {
+		return message, nil
+	}
+	return p.format.ReplaceScope(message, p.scopes[0])
+}
+
+// validate rejects scopes that do not match the touched packages when scope
+// enforcement is enabled.
+func (p packageScopes) validate(message string) error {
+	if !p.enforce || len(p.scopes) == 0 {
+		return nil
+	}
+	h, err := p.format.ParseHeader(firstLine(message))
+	if err != nil {
+		return err
+	}
+	for _, s := range p.scopes {
+		if h.Scope == s {
+			return nil
+		}
+	}
+	if h.Scope == "" {
+		return fmt.Errorf("commit scope is required for changes to monorepo packages (expected one of: %s)", strings.Join(p.scopes, ", "))
+	}
+	return fmt.Errorf("commit scope %q does not match the touched packages (expected one of: %s)", h.Scope, strings.Join(p.scopes, ", "))
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return line
+}
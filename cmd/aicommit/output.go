@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes for scripts. Other errors exit with exitFailure.
+const (
+	exitFailure         = 1
+	exitNoStagedChanges = 2
+	exitProviderError   = 3
+	exitInvalidMessage  = 4
+	exitReviewFailed    = 5
+)
+
+var (
+	printMessage bool
+	jsonOutput   bool
+)
+
+// progress receives status messages. With --print or --json it is stderr,
+// so that stdout only carries the result.
+var progress io.Writer = os.Stdout
+
+// addOutputFlags registers --print and --json, which write the generated
+// message to stdout instead of opening an editor.
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&printMessage, "print", false, "print only the generated message to stdout (progress goes to stderr); no editor, no commit")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the message, its parts, provider, model, token usage and validation results as JSON; no editor, no commit")
+	cmd.MarkFlagsMutuallyExclusive("print", "json")
+}
+
+// machineOutput reports whether the result is written for scripts.
+func machineOutput() bool {
+	return printMessage || jsonOutput
+}
+
+// redirectProgress sends all status output of cmd to stderr for --print and
+// --json.
+func redirectProgress(cmd *cobra.Command) {
+	if machineOutput() {
+		progress = os.Stderr
+		cmd.SetOut(os.Stderr)
+	}
+}
+
+// exitError sets the exit code of a failed command.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// exitCode returns the process exit code for err.
+func exitCode(err error) int {
+	var exitErr *exitError
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.Is(err, git.ErrNoStagedChanges):
+		return exitNoStagedChanges
+	}
+	return exitFailure
+}
+
+// generationResult is the --json output of the commit and tag commands.
+type generationResult struct {
+	Message string `json:"message"`
+	// Parts is the parsed commit message; nil if it does not match the format.
+	Parts      *prompt.StructuredCommit `json:"parts,omitempty"`
+	Version    string                   `json:"version,omitempty"`
+	Release    *tagRelease              `json:"release,omitempty"`
+	Provider   string                   `json:"provider"`
+	Model      string                   `json:"model"`
+	Usage      *model.Usage             `json:"usage"`
+	Valid      bool                     `json:"valid"`
+	Violations []prompt.Violation       `json:"violations"`
+}
+
+// tagRelease is the release a tag message would publish with --release.
+type tagRelease struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+func newGenerationResult(cfg *config.Config, provider model.Provider, message string) generationResult {
+	result := generationResult{
+		Message:    message,
+		Provider:   provider.Name(),
+		Model:      resolveModelName(cfg),
+		Valid:      true,
+		Violations: []prompt.Violation{},
+	}
+	if reporter, ok := provider.(model.UsageReporter); ok {
+		usage := reporter.Usage()
+		result.Usage = &usage
+	}
+	return result
+}
+
+// writeResult prints result for --print or --json.
+func writeResult(result generationResult) error {
+	if printMessage {
+		_, err := fmt.Fprintln(os.Stdout, result.Message)
+		return err
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
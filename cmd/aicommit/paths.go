@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aicommit/aicommit/internal/git"
+)
+
+var (
+	stageAll         bool
+	includeUntracked bool
+)
+
+// selectChanges selects the changes to commit like git commit: -a adds the
+// changes of tracked files, --include-untracked adds new files, and paths
+// restrict the commit to the working tree content of those paths. The index
+// is only updated when the commit is made.
+func selectChanges(gitClient *git.Git, paths []string) error {
+	if stageAll && len(paths) > 0 {
+		return fmt.Errorf("paths with -a does not make sense")
+	}
+
+	var untracked []string
+	if includeUntracked {
+		var err error
+		if untracked, err = gitClient.UntrackedFiles(paths...); err != nil {
+			return err
+		}
+		if len(untracked) == 0 {
+			return fmt.Errorf("--include-untracked: no untracked files to include")
+		}
+		fmt.Fprintf(progress, "Including %d untracked file(s)\n", len(untracked))
+	}
+	if err := gitClient.StageOnCommit(stageAll, untracked); err != nil {
+		return err
+	}
+
+	if len(paths) > 0 {
+		if err := gitClient.CheckPaths(paths...); err != nil {
+			return err
+		}
+		gitClient.SetCommitPaths(paths)
+	}
+	return nil
+}
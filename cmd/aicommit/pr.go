@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultPRCommitLimit    = 100
+	defaultPRDiffStatMaxLen = 4000
+	defaultPRDiffMaxLen     = 64 * 1024
+)
+
+func newPRCmd() *cobra.Command {
+	var outputFile string
+	var templateFile string
+
+	cmd := &cobra.Command{
+		Use:   "pr [base]",
+		Short: "Generate a pull request title and description with AI",
+		Long: `Generate a pull request title and Markdown description from the commits and
+diff of the current branch since it diverged from [base] (default: origin/HEAD,
+main or master).
+
+Use --output to write the description to a file for
+"gh pr create --title <title> --body-file <file>".`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPR(cmd, args, outputFile, templateFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "write the description to this file instead of stdout")
+	cmd.Flags().StringVar(&templateFile, "template", "", "Markdown template for the description (overrides pr.template)")
+	return cmd
+}
+
+func runPR(cmd *cobra.Command, args []string, outputFile, templateFile string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	gitClient, err := mustOpenRepo()
+	if err != nil {
+		return err
+	}
+	format, err := commitFormat(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+	language, err := promptLanguage(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+
+	base := ""
+	if len(args) > 0 {
+		base = strings.TrimSpace(args[0])
+	}
+	if base == "" {
+		base, err = gitClient.DefaultBranch()
+		if err != nil {
+			return err
+		}
+	}
+
+	if templateFile == "" {
+		templateFile = cfg.PR.Template
+	}
+	bodyTemplate, err := loadPRBodyTemplate(templateFile)
+	if err != nil {
+		return err
+	}
+
+	infoBlock, err := buildPRContext(gitClient, base)
+	if err != nil {
+		return err
+	}
+
+	title, body, err := generatePRMessage(cfg, format, language, bodyTemplate, infoBlock)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFile == "" {
+		fmt.Fprintf(out, "\n%s\n\n%s\n", title, body)
+		return nil
+	}
+
+	if err := os.WriteFile(outputFile, []byte(body+"\n"), 0o644); err != nil { // #nosec G306 -- PR descriptions are not secret.
+		return fmt.Errorf("failed to write pull request description: %w", err)
+	}
+
+	fmt.Fprintf(out, "\nTitle: %s\n", title)
+	fmt.Fprintf(out, "Description written to %s\n", outputFile)
+	fmt.Fprintf(out, "Create the pull request with: gh pr create --base %s --title %q --body-file %s\n", base, title, outputFile)
+	return nil
+}
+
+func loadPRBodyTemplate(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- Path comes from the user's own config or flags.
+	if err != nil {
+		return "", fmt.Errorf("failed to read pull request template: %w", err)
+	}
+	return string(data), nil
+}
+
+func buildPRContext(gitClient *git.Git, base string) (string, error) {
+	mergeBase, err := gitClient.MergeBase(base, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	rangeSpec := fmt.Sprintf("%s..HEAD", mergeBase)
+
+	messages, truncated, err := gitClient.CommitMessages(rangeSpec, defaultPRCommitLimit)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit messages: %w", err)
+	}
+	if len(messages) == 0 {
+		return "", fmt.Errorf("no commits on the current branch since %s", base)
+	}
+
+	branch, err := gitClient.CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+
+	diffStat := formatOrUnavailable(func() (string, error) { return gitClient.DiffStat(rangeSpec) }, defaultPRDiffStatMaxLen)
+	diff := formatOrUnavailable(func() (string, error) { return gitClient.DiffRange(rangeSpec) }, defaultPRDiffMaxLen)
+
+	var b strings.Builder
+	b.WriteString("Branch: ")
+	b.WriteString(branch)
+	b.WriteString("\n")
+	b.WriteString("Base: ")
+	b.WriteString(base)
+	b.WriteString("\n")
+
+	b.WriteString("\nCommits (newest first):\n")
+	for _, m := range messages {
+		b.WriteString("- ")
+		b.WriteString(strings.ReplaceAll(m, "\n", "\n  "))
+		b.WriteString("\n")
+	}
+	if truncated {
+		b.WriteString("(commit list truncated)\n")
+	}
+
+	b.WriteString("\nDiffstat:\n")
+	b.WriteString(diffStat)
+	b.WriteString("\n")
+
+	b.WriteString("\nDiff:\n")
+	b.WriteString(diff)
+	b.WriteString("\n")
+
+	return b.String(), nil
+}
+
+// longFormMaxTokens is the response limit for pull request descriptions,
+// changelogs, explanations and reviews, which need far more room than a
+// commit message.
+const longFormMaxTokens = 4096
+
+// generateText asks provider for a long-form response. Providers without a
+// per-call limit use their commit message settings.
+func generateText(ctx context.Context, provider model.Provider, input string) (string, error) {
+	if p, ok := provider.(model.TextProvider); ok {
+		return p.GenerateText(ctx, input, longFormMaxTokens)
+	}
+	return provider.GenerateMessage(ctx, input)
+}
+
+func generatePRMessage(cfg *config.Config, format prompt.Format, language prompt.Language, bodyTemplate, infoBlock string) (title, body string, err error) {
+	provider, err := model.NewProvider(cfg)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create provider: %w", err)
+	}
+	template := prompt.NewPRTemplate(bodyTemplate)
+	template.SetFormat(format)
+	template.SetLanguage(language)
+	provider.SetTemplate(template)
+
+	fmt.Fprintf(progress, "Generating pull request description using %s with model %s...\n", provider.Name(), resolveModelName(cfg))
+
+	text, err := generateText(context.Background(), provider, infoBlock)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate pull request description: %w", err)
+	}
+
+	title, body, err = prompt.ParsePRMessage(text)
+	if err != nil {
+		return "", "", fmt.Errorf("generated pull request description is invalid: %w", err)
+	}
+	return title, body, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/forge"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/pkg/semver"
+)
+
+const defaultPushRemote = "origin"
+
+// newReleaseClient creates the forge client for the repository behind remote.
+// It runs before the tag is created so that configuration problems do not
+// leave a half-finished release behind.
+func newReleaseClient(cfg *config.Config, gitClient *git.Git, remote string) (forge.Client, error) {
+	remoteURL, err := gitClient.RemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(cfg.Release.Forge))
+	if kind == "" {
+		repo, err := forge.ParseRemoteURL(remoteURL)
+		if err != nil {
+			return nil, err
+		}
+		kind, err = forge.DetectKind(repo.Host)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := forge.NewClient(kind, cfg.Release.BaseURL, cfg.GetReleaseToken(kind), remoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create release client: %w", err)
+	}
+	return client, nil
+}
+
+// publishRelease creates the release for tag. tagPrefix is the package
+// prefix of monorepo tags, e.g. "libs/auth/".
+func publishRelease(client forge.Client, tag, tagPrefix, tagMessage string) (string, error) {
+	release := forge.ReleaseFromTagMessage(tag, tagMessage)
+	if v, err := semver.Parse(strings.TrimPrefix(tag, tagPrefix)); err == nil {
+		release.Prerelease = v.IsPrerelease()
+	}
+
+	fmt.Fprintf(progress, "Creating %s release for %s...\n", client.Name(), tag)
+
+	url, err := client.CreateRelease(context.Background(), release)
+	if err != nil {
+		return "", err
+	}
+	return url, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aicommit/aicommit/internal/forge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReleaseClient struct {
+	release forge.Release
+}
+
+func (c *fakeReleaseClient) CreateRelease(ctx context.Context, release forge.Release) (string, error) {
+	c.release = release
+	return "https://example.com/releases/1", nil
+}
+
+func (c *fakeReleaseClient) Name() string { return "fake" }
+
+func TestPublishRelease_Prerelease(t *testing.T) {
+	tests := []struct {
+		tag, prefix string
+		want        bool
+	}{
+		{"v1.4.0", "", false},
+		{"v1.4.0-rc.1", "", true},
+		{"libs/auth/v1.4.0-rc.1", "libs/auth/", true},
+		{"libs/auth/v1.4.0", "libs/auth/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			client := &fakeReleaseClient{}
+			_, err := publishRelease(client, tt.tag, tt.prefix, "Release "+tt.tag+"\n\nNotes")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, client.release.Prerelease)
+		})
+	}
+}
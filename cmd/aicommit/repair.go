@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// messageRepairer turns a rejected generated message into a valid one:
+// deterministic fixes first, then up to attempts follow-up model requests
+// that quote the violations. Valid messages are only decorated.
+type messageRepairer struct {
+	provider model.Provider
+	template prompt.Template
+	input    string
+	attempts int
+	// fix applies the deterministic fixes; it only runs on messages with
+	// error-level violations.
+	fix func(message string) string
+	// decorate adds what every message gets, e.g. scopes and trailers.
+	decorate func(message string) (string, error)
+	validate commitValidator
+}
+
+// repair returns the best message it could produce and its remaining
+// violations. It fails only if decorating a message fails. The provider's
+// template is restored afterwards.
+func (r messageRepairer) repair(ctx context.Context, message string) (string, []prompt.Violation, error) {
+	message, violations, err := r.check(message)
+	if err != nil || !prompt.HasErrors(violations) {
+		return message, violations, err
+	}
+	defer r.provider.SetTemplate(r.template)
+
+	for attempt := 1; attempt <= r.attempts; attempt++ {
+		fmt.Fprintf(progress, "Generated commit message is invalid, asking %s to fix it (attempt %d/%d)...\n", r.provider.Name(), attempt, r.attempts)
+
+		r.provider.SetTemplate(prompt.NewRepairTemplate(r.template, message, errorViolations(violations)))
+		repaired, err := r.provider.GenerateMessage(ctx, r.input)
+		if err != nil {
+			fmt.Fprintf(progress, "Warning: failed to repair commit message: %v\n", err)
+			break
+		}
+
+		message, violations, err = r.check(prompt.CleanCommitMessage(repaired))
+		if err != nil {
+			return "", nil, err
+		}
+		if !prompt.HasErrors(violations) {
+			break
+		}
+	}
+	return message, violations, nil
+}
+
+// check decorates and validates message, applying the deterministic fixes
+// only if it has errors.
+func (r messageRepairer) check(message string) (string, []prompt.Violation, error) {
+	message, err := r.decorate(message)
+	if err != nil {
+		return "", nil, err
+	}
+	violations := r.validate(message)
+	if !prompt.HasErrors(violations) {
+		return message, violations, nil
+	}
+	if message, err = r.decorate(r.fix(message)); err != nil {
+		return "", nil, err
+	}
+	return message, r.validate(message), nil
+}
+
+func errorViolations(violations []prompt.Violation) []prompt.Violation {
+	var errs []prompt.Violation
+	for _, v := range violations {
+		if v.Severity == prompt.SeverityError {
+			errs = append(errs, v)
+		}
+	}
+	return errs
+}
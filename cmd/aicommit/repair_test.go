@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	responses []string
+	calls     int
+}
+
+func (p *fakeProvider) GenerateMessage(ctx context.Context, input string) (string, error) {
+	response := p.responses[p.calls]
+	p.calls++
+	return response, nil
+}
+
+func (p *fakeProvider) SetTemplate(template prompt.Template) {}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func newTestRepairer(provider *fakeProvider, rules prompt.Rules) messageRepairer {
+	return messageRepairer{
+		provider: provider,
+		attempts: 1,
+		fix: func(message string) string {
+			return prompt.FixCommitMessage(message, rules)
+		},
+		decorate: func(message string) (string, error) { return message, nil },
+		validate: func(message string) []prompt.Violation {
+			return prompt.LintCommitMessage(message, rules)
+		},
+	}
+}
+
+func TestMessageRepairer(t *testing.T) {
+	rules := prompt.DefaultRules()
+	rules.BodyMaxLineLength = 20
+
+	t.Run("valid message passes through unchanged", func(t *testing.T) {
+		provider := &fakeProvider{}
+		message := "feat: Add login endpoint\n\nThis body line is longer than twenty characters."
+
+		got, violations, err := newTestRepairer(provider, rules).repair(context.Background(), message)
+		require.NoError(t, err)
+		assert.Equal(t, message, got)
+		assert.False(t, prompt.HasErrors(violations))
+		assert.Zero(t, provider.calls)
+	})
+
+	t.Run("invalid message is fixed without the provider", func(t *testing.T) {
+		provider := &fakeProvider{}
+
+		got, violations, err := newTestRepairer(provider, rules).repair(context.Background(), "Feat: add login endpoint.\nMissing blank line.")
+		require.NoError(t, err)
+		assert.Equal(t, "feat: add login endpoint\n\nMissing blank line.", got)
+		assert.False(t, prompt.HasErrors(violations))
+		assert.Zero(t, provider.calls)
+	})
+
+	t.Run("asks the provider when fixes are not enough", func(t *testing.T) {
+		provider := &fakeProvider{responses: []string{"fix: handle empty input"}}
+
+		got, violations, err := newTestRepairer(provider, rules).repair(context.Background(), "handle empty input")
+		require.NoError(t, err)
+		assert.Equal(t, "fix: handle empty input", got)
+		assert.False(t, prompt.HasErrors(violations))
+		assert.Equal(t, 1, provider.calls)
+	})
+	t.Run("decorate errors are returned", func(t *testing.T) {
+		r := newTestRepairer(&fakeProvider{}, rules)
+		r.decorate = func(message string) (string, error) {
+			return "", errors.New("failed to add trailers")
+		}
+
+		_, _, err := r.repair(context.Background(), "fix: handle empty input")
+		assert.EqualError(t, err, "failed to add trailers")
+	})
+}
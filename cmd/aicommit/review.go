@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/pkg/editor"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// commitReview holds what the editor buffer shows besides the message.
+type commitReview struct {
+	editor   string
+	validate commitValidator
+	// commentChar is git's core.commentChar setting, possibly "auto".
+	commentChar string
+	rules       []string
+	changes     []string
+	// diff is shown below the scissors line when commit.verbose or
+	// --verbose is set.
+	diff string
+}
+
+// newCommitReview prepares the editor buffer for a commit whose changes are
+// described by nameStatus (git diff --name-status output) and diff.
+func newCommitReview(cfg *config.Config, gitClient *git.Git, rules prompt.Rules, validate commitValidator, nameStatus, diff string) (commitReview, error) {
+	editorCmd, err := resolveEditor(cfg, gitClient)
+	if err != nil {
+		return commitReview{}, err
+	}
+
+	review := commitReview{
+		editor:      editorCmd,
+		validate:    validate,
+		commentChar: gitClient.CommentChar(),
+		rules:       rules.Describe(),
+		changes:     changeLines(nameStatus),
+	}
+	if verbose || gitClient.Verbose() {
+		review.diff = diff
+	}
+	return review, nil
+}
+
+// resolveEditor picks the editor like git does, preferring aicommit's own
+// editor setting.
+func resolveEditor(cfg *config.Config, gitClient *git.Git) (string, error) {
+	return editor.Resolve(cfg.Editor, gitClient.CoreEditor())
+}
+
+// changeLines formats name-status output like the file list of git status.
+func changeLines(nameStatus string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(nameStatus), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+
+		path := fields[1]
+		status := "modified"
+		switch fields[0][0] {
+		case 'A':
+			status = "new file"
+		case 'D':
+			status = "deleted"
+		case 'T':
+			status = "typechange"
+		case 'R', 'C':
+			status = "renamed"
+			if fields[0][0] == 'C' {
+				status = "copied"
+			}
+			if len(fields) > 2 {
+				path = fields[1] + " -> " + fields[2]
+			}
+		}
+		lines = append(lines, fmt.Sprintf("\t%-12s%s", status+":", path))
+	}
+	return lines
+}
+
+// buffer renders the editor content for message and its violations.
+func (r commitReview) buffer(message string, violations []prompt.Violation) (content, commentChar string) {
+	commentChar = editor.ResolveCommentChar(r.commentChar, message)
+
+	sections := [][]string{{
+		"Please enter the commit message for your changes. Lines starting",
+		fmt.Sprintf("with '%s' will be ignored, and an empty message aborts the commit.", commentChar),
+	}}
+	if len(violations) > 0 {
+		section := []string{"The commit message violates these rules:"}
+		for _, v := range violations {
+			section = append(section, "  "+v.String())
+		}
+		sections = append(sections, section)
+	}
+	if len(r.rules) > 0 {
+		sections = append(sections, append([]string{"Commit message rules:"}, indent(r.rules, "  ")...))
+	}
+	if len(r.changes) > 0 {
+		sections = append(sections, append([]string{"Changes to be committed:"}, r.changes...))
+	}
+
+	tpl := editor.Template{
+		Message:     message,
+		CommentChar: commentChar,
+		Sections:    sections,
+		Diff:        r.diff,
+	}
+	return tpl.Render(), commentChar
+}
+
+func indent(lines []string, prefix string) []string {
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, prefix+l)
+	}
+	return out
+}
+
+// reviewCommitMessage opens the message in the editor until it passes
+// validation. Comments and everything below the scissors line are removed
+// before the message is validated.
+func reviewCommitMessage(commitMessage string, review commitReview) (string, error) {
+	violations := review.validate(commitMessage)
+	for attempt := 0; attempt < 3; attempt++ {
+		content, commentChar := review.buffer(commitMessage, violations)
+
+		fmt.Println("\nOpening editor to review/edit commit message...")
+		newCommitMessage, err := editor.Open(content, review.editor)
+		if err != nil {
+			return "", fmt.Errorf("failed to open editor: %w", err)
+		}
+
+		commitMessage = editor.Cleanup(newCommitMessage, commentChar)
+		if commitMessage == "" {
+			fmt.Println("\nCommit message is empty, aborting commit.")
+			return "", nil
+		}
+
+		violations = review.validate(commitMessage)
+		if prompt.HasErrors(violations) {
+			fmt.Println("\nCommit message is invalid:")
+			printViolations(violations)
+			continue
+		}
+		if len(violations) > 0 {
+			fmt.Println("\nWarnings:")
+			printViolations(violations)
+		}
+
+		return commitMessage, nil
+	}
+
+	return "", fmt.Errorf("commit message is still invalid after multiple edits")
+}
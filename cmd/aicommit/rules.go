@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/pkg/commitlint"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// commitValidator checks a commit message and returns all rule violations.
+type commitValidator func(message string) []prompt.Violation
+
+// commitRules converts the rules config into prompt.Rules for the commit
+// format and applies the repository's commitlint config on top.
+func commitRules(cfg *config.Config, gitClient *git.Git) (prompt.Rules, error) {
+	format, err := commitFormat(cfg, gitClient)
+	if err != nil {
+		return prompt.Rules{}, err
+	}
+
+	r := cfg.Rules
+	rules := prompt.Rules{
+		Types:               r.Types,
+		Scopes:              r.Scopes,
+		ScopeRequired:       r.ScopeRequired,
+		HeaderMaxLength:     r.HeaderMaxLength,
+		SubjectCase:         strings.ToLower(strings.TrimSpace(r.SubjectCase)),
+		SubjectNoPeriod:     r.SubjectNoPeriod,
+		BodyMaxLineLength:   r.BodyMaxLineLength,
+		RequiredTrailers:    r.RequiredTrailers,
+		ForbiddenTrailers:   r.ForbiddenTrailers,
+		BreakingConsistency: r.BreakingConsistency,
+	}
+
+	switch rules.SubjectCase {
+	case prompt.SubjectCaseAny, prompt.SubjectCaseLower, prompt.SubjectCaseSentence:
+	default:
+		return prompt.Rules{}, fmt.Errorf("invalid rules.subject_case %q (expected lower or sentence)", r.SubjectCase)
+	}
+
+	if len(r.Severity) > 0 {
+		rules.Severities = make(map[string]prompt.Severity, len(r.Severity))
+		for rule, sev := range r.Severity {
+			name := strings.ToLower(strings.TrimSpace(rule))
+			if !prompt.IsRule(name) {
+				return prompt.Rules{}, fmt.Errorf("unknown rule %q in rules.severity (expected one of %s)", rule, strings.Join(prompt.RuleNames(), ", "))
+			}
+			s := prompt.Severity(strings.ToLower(strings.TrimSpace(sev)))
+			switch s {
+			case prompt.SeverityOff, prompt.SeverityWarning, prompt.SeverityError:
+			default:
+				return prompt.Rules{}, fmt.Errorf("invalid severity %q for rule %s (expected error, warning or off)", sev, rule)
+			}
+			rules.Severities[name] = s
+		}
+	}
+
+	return applyCommitlint(rules.WithFormat(format), strings.TrimSpace(r.Commitlint), gitClient)
+}
+
+// commitFormat resolves the commit format: the repository's aicommit.format,
+// then the format setting.
+func commitFormat(cfg *config.Config, gitClient *git.Git) (prompt.Format, error) {
+	value := gitClient.CommitFormat()
+	if value == "" {
+		value = cfg.Format
+	}
+	format, err := prompt.ParseFormat(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid format: %w", err)
+	}
+	return format, nil
+}
+
+func applyCommitlint(rules prompt.Rules, path string, gitClient *git.Git) (prompt.Rules, error) {
+	if strings.EqualFold(path, "off") {
+		return rules, nil
+	}
+
+	var lintCfg commitlint.Config
+	if path != "" {
+		var err error
+		lintCfg, err = commitlint.Load(path)
+		if err != nil {
+			return prompt.Rules{}, err
+		}
+	} else {
+		root, err := gitClient.TopLevel()
+		if err != nil {
+			return prompt.Rules{}, err
+		}
+		lintCfg, path, err = commitlint.Discover(root)
+		if errors.Is(err, commitlint.ErrNotFound) {
+			return rules, nil
+		}
+		if errors.Is(err, commitlint.ErrUnsupportedFormat) {
+			fmt.Fprintf(progress, "Note: %v\n", err)
+			return rules, nil
+		}
+		if err != nil {
+			return prompt.Rules{}, fmt.Errorf("%w (set rules.commitlint: off to ignore it)", err)
+		}
+	}
+
+	rules, unsupported := lintCfg.Apply(rules)
+	if len(unsupported) > 0 {
+		fmt.Fprintf(progress, "Note: ignoring unsupported commitlint settings in %s: %s\n", path, strings.Join(unsupported, ", "))
+	}
+	return rules, nil
+}
+
+// newCommitValidator checks messages against rules and, for monorepos, the
+// scopes of the touched packages.
+func newCommitValidator(rules prompt.Rules, scopes packageScopes) commitValidator {
+	return func(message string) []prompt.Violation {
+		violations := prompt.LintCommitMessage(message, rules)
+		if prompt.HasErrors(violations) {
+			return violations
+		}
+		if err := scopes.validate(message); err != nil {
+			violations = append(violations, prompt.Violation{Rule: prompt.RuleScopeEnum, Severity: prompt.SeverityError, Message: err.Error()})
+		}
+		return violations
+	}
+}
+
+func printViolations(violations []prompt.Violation) {
+	for _, v := range violations {
+		fmt.Fprintf(progress, "  %s\n", v)
+	}
+}
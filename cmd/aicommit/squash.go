@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/spf13/cobra"
+)
+
+const defaultSquashCommitLimit = 100
+
+func newSquashCmd() *cobra.Command {
+	var commit bool
+
+	cmd := &cobra.Command{
+		Use:   "squash <base> [head]",
+		Short: "Generate one commit message for squashing <base>..<head> with AI",
+		Long: `Generate a single commit message in the configured format that summarises
+all commits between the merge base of <base> and <head> (default HEAD).
+
+With --commit the squash is performed as well:
+  - if <head> is the current HEAD, the branch is reset (--soft) to the merge base
+    and recommitted as one commit;
+  - otherwise <head> is squash-merged (git merge --squash) into the current branch,
+    which must be <base>.
+If the commit fails, the branch is restored.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSquash(cmd, args, commit)
+		},
+	}
+
+	cmd.Flags().BoolVar(&commit, "commit", false, "perform the squash and commit with the generated message")
+	return cmd
+}
+
+func runSquash(cmd *cobra.Command, args []string, commit bool) error {
+	base := strings.TrimSpace(args[0])
+	head := "HEAD"
+	if len(args) > 1 && strings.TrimSpace(args[1]) != "" {
+		head = strings.TrimSpace(args[1])
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	gitClient, err := mustOpenRepo()
+	if err != nil {
+		return err
+	}
+	rules, err := commitRules(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+	language, err := promptLanguage(cfg, gitClient)
+	if err != nil {
+		return err
+	}
+	gitClient.SetSigning(signOptions(cfg))
+
+	mergeBase, err := gitClient.MergeBase(base, head)
+	if err != nil {
+		return err
+	}
+
+	var plan squashPlan
+	if commit && !dryRun {
+		if plan, err = planSquash(gitClient, base, head); err != nil {
+			return err
+		}
+	}
+
+	infoBlock, err := buildSquashContext(gitClient, base, head, mergeBase)
+	if err != nil {
+		return err
+	}
+
+	validate := newCommitValidator(rules, packageScopes{})
+	commitMessage, violations, err := generateSquashMessage(cfg, rules, validate, language, infoBlock)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "\nGenerated squash commit message:\n%s\n", commitMessage)
+	if prompt.HasErrors(violations) {
+		fmt.Fprintln(progress, "\nGenerated commit message is still invalid:")
+		printViolations(violations)
+	} else if len(violations) > 0 {
+		fmt.Fprintln(progress, "\nWarnings:")
+		printViolations(violations)
+	}
+
+	if dryRun || !commit {
+		if err := invalidMessageError(violations); err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Fprintln(out, "\nDry run mode - no commit was made")
+		}
+		return nil
+	}
+
+	staged, err := gitClient.HasStagedChanges()
+	if err != nil {
+		return fmt.Errorf("failed to check staged changes: %w", err)
+	}
+	if staged {
+		return fmt.Errorf("staged changes would be included in the squash commit; commit or stash them first")
+	}
+
+	rangeSpec := fmt.Sprintf("%s..%s", mergeBase, head)
+	nameStatus, _ := gitClient.DiffNameStatus(rangeSpec)
+	diff := ""
+	if verbose || gitClient.Verbose() {
+		diff, _ = gitClient.DiffRange(rangeSpec)
+	}
+	review, err := newCommitReview(cfg, gitClient, rules, validate, nameStatus, diff)
+	if err != nil {
+		return err
+	}
+	commitMessage, err = reviewCommitMessage(commitMessage, review)
+	if err != nil {
+		return err
+	}
+	if commitMessage == "" {
+		return nil
+	}
+
+	if err := plan.apply(gitClient, head, mergeBase); err != nil {
+		return err
+	}
+
+	if err := gitClient.Commit(commitMessage); err != nil {
+		plan.undo(gitClient, out)
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	fmt.Fprintln(out, "\nSquash commit successful!")
+	return nil
+}
+
+func buildSquashContext(gitClient *git.Git, base, head, mergeBase string) (string, error) {
+	rangeSpec := fmt.Sprintf("%s..%s", mergeBase, head)
+
+	messages, truncated, err := gitClient.CommitMessages(rangeSpec, defaultSquashCommitLimit)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit messages: %w", err)
+	}
+	if len(messages) == 0 {
+		return "", fmt.Errorf("no commits to squash between %s and %s", base, head)
+	}
+
+	diff, err := gitClient.DiffRange(rangeSpec)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	return buildSquashInfoBlock(base, head, messages, truncated, diff), nil
+}
+
+// squashPlan is how --commit squashes: by resetting the current branch to
+// the merge base, or by squash-merging head into base.
+type squashPlan struct {
+	reset bool
+	// original is the commit HEAD pointed to before a reset.
+	original string
+}
+
+// planSquash picks the squash method and refuses to squash-merge into a
+// branch other than base.
+func planSquash(gitClient *git.Git, base, head string) (squashPlan, error) {
+	headCommit, err := gitClient.ResolveRevision(head)
+	if err != nil {
+		return squashPlan{}, err
+	}
+	currentCommit, err := gitClient.ResolveRevision("HEAD")
+	if err != nil {
+		return squashPlan{}, err
+	}
+	if headCommit == currentCommit {
+		return squashPlan{reset: true, original: currentCommit}, nil
+	}
+
+	branch, err := gitClient.CurrentBranch()
+	if err != nil {
+		return squashPlan{}, err
+	}
+	baseCommit, err := gitClient.ResolveRevision(base)
+	if err != nil {
+		return squashPlan{}, err
+	}
+	if branch != base && baseCommit != currentCommit {
+		return squashPlan{}, fmt.Errorf("squash-merging %s requires %s to be checked out (current branch: %s)", head, base, branch)
+	}
+	return squashPlan{}, nil
+}
+
+// apply rewrites the history so that the index holds the combined change of
+// mergeBase..head, ready to be committed.
+func (p squashPlan) apply(gitClient *git.Git, head, mergeBase string) error {
+	if p.reset {
+		return gitClient.SoftReset(mergeBase)
+	}
+	return gitClient.MergeSquash(head)
+}
+
+// undo restores the branch after a failed commit, or tells the user how to.
+func (p squashPlan) undo(gitClient *git.Git, out io.Writer) {
+	if !p.reset {
+		fmt.Fprintln(out, "\nThe squashed changes are still staged; run \"git reset --merge\" to drop them.")
+		return
+	}
+	if err := gitClient.SoftReset(p.original); err != nil {
+		fmt.Fprintf(out, "\nFailed to restore the branch: %v\nRun \"git reset --soft %s\" to restore it.\n", err, p.original)
+		return
+	}
+	fmt.Fprintf(out, "\nThe branch was restored to %s.\n", p.original)
+}
+
+// generateSquashMessage generates and, like run, repairs the squash commit
+// message; it returns the remaining violations.
+func generateSquashMessage(cfg *config.Config, rules prompt.Rules, validate commitValidator, language prompt.Language, infoBlock string) (string, []prompt.Violation, error) {
+	provider, err := model.NewProvider(cfg)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+	template := prompt.NewSquashTemplate()
+	template.SetFormat(rules.Format)
+	template.SetLanguage(language)
+	provider.SetTemplate(template)
+
+	fmt.Fprintf(progress, "Generating squash commit message using %s with model %s...\n", provider.Name(), resolveModelName(cfg))
+
+	ctx := context.Background()
+	commitMessage, err := provider.GenerateMessage(ctx, infoBlock)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate commit message: %w", err)
+	}
+
+	repairer := messageRepairer{
+		provider: provider,
+		template: template,
+		input:    infoBlock,
+		attempts: cfg.Rules.RepairAttempts,
+		fix: func(message string) string {
+			return prompt.FixCommitMessage(message, rules)
+		},
+		decorate: func(message string) (string, error) { return message, nil },
+		validate: validate,
+	}
+	return repairer.repair(ctx, prompt.CleanCommitMessage(commitMessage))
+}
+
+func buildSquashInfoBlock(base, head string, messages []string, truncated bool, diff string) string {
+	var b strings.Builder
+	b.WriteString("Base: ")
+	b.WriteString(base)
+	b.WriteString("\n")
+	b.WriteString("Head: ")
+	b.WriteString(head)
+	b.WriteString("\n")
+
+	b.WriteString("\nCommits being squashed (newest first):\n")
+	for _, m := range messages {
+		lines := strings.Split(m, "\n")
+		b.WriteString("- ")
+		b.WriteString(strings.TrimSpace(lines[0]))
+		b.WriteString("\n")
+		for _, line := range lines[1:] {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			b.WriteString("    ")
+			b.WriteString(strings.TrimRight(line, " \t"))
+			b.WriteString("\n")
+		}
+	}
+	if truncated {
+		b.WriteString("(commit list truncated)\n")
+	}
+
+	b.WriteString("\nCombined diff:\n")
+	b.WriteString(diff)
+	b.WriteString("\n")
+
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// generateCommitMessage returns the cleaned commit message for diff. With
+// structured_output the provider is asked for JSON fields and the message is
+// assembled from them; responses that cannot be decoded, and providers
+// without JSON support, fall back to the text response.
+func generateCommitMessage(ctx context.Context, cfg *config.Config, provider model.Provider, template prompt.Template, format prompt.Format, diff string) (string, error) {
+	structured, ok := provider.(model.StructuredProvider)
+	if !cfg.StructuredOutput || !ok {
+		if cfg.StructuredOutput {
+			fmt.Fprintf(progress, "Note: %s does not support structured output; parsing the text response\n", provider.Name())
+		}
+		message, err := provider.GenerateMessage(ctx, diff)
+		if err != nil {
+			return "", err
+		}
+		return prompt.CleanCommitMessage(message), nil
+	}
+
+	provider.SetTemplate(prompt.NewStructuredTemplate(template))
+	defer provider.SetTemplate(template)
+
+	response, err := structured.GenerateJSON(ctx, diff, prompt.CommitSchema())
+	if err != nil {
+		return "", err
+	}
+	commit, err := prompt.ParseStructuredCommit(response)
+	if err != nil {
+		fmt.Fprintf(progress, "Warning: %v; parsing the response as text\n", err)
+		return prompt.CleanCommitMessage(response), nil
+	}
+	return commit.Message(format), nil
+}
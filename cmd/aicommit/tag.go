@@ -5,13 +5,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/forge"
 	"github.com/aicommit/aicommit/internal/git"
 	"github.com/aicommit/aicommit/internal/model"
+	"github.com/aicommit/aicommit/internal/monorepo"
 	"github.com/aicommit/aicommit/pkg/editor"
 	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/aicommit/aicommit/pkg/semver"
 	"github.com/aicommit/aicommit/pkg/validator"
 	"github.com/spf13/cobra"
 )
@@ -20,28 +24,121 @@ const (
 	defaultTagCommitLimit      = 50
 	defaultTagDiffStatMaxLen   = 4000
 	defaultTagNameStatusMaxLen = 4000
+	// defaultTagCommitBodiesMaxLen bounds the commit bodies included in the
+	// tag context; subjects are always included.
+	defaultTagCommitBodiesMaxLen = 8000
 )
 
+type tagOptions struct {
+	version       string
+	bump          string
+	preid         string
+	changelog     bool
+	changelogFile string
+	push          string
+	release       bool
+	pkg           string
+	rng           tagRangeOptions
+}
+
+// tagRangeOptions selects the commit range that release notes and version
+// bumps are computed from: (previous tag or --from)..(--to).
+type tagRangeOptions struct {
+	from           string
+	to             string
+	match          string
+	skipPrerelease bool
+	// tagPrefix and paths are set by --package: versions are prefixed with
+	// tagPrefix and only commits touching paths are considered.
+	tagPrefix string
+	paths     []string
+}
+
+func addTagRangeFlags(cmd *cobra.Command, rng *tagRangeOptions) {
+	cmd.Flags().StringVar(&rng.from, "from", "", "start of the range (default: previous tag reachable from --to)")
+	cmd.Flags().StringVar(&rng.to, "to", "HEAD", "end of the range")
+	cmd.Flags().StringVar(&rng.match, "match", "", "only consider previous tags matching this glob (e.g. 'api/v*')")
+	cmd.Flags().BoolVar(&rng.skipPrerelease, "skip-prerelease", false, "ignore prerelease tags (e.g. v1.2.0-rc.1) when finding the previous tag")
+}
+
+// resolve returns the start of the range (ok is false when there is neither
+// --from nor a previous tag) and its end.
+func (o tagRangeOptions) resolve(gitClient *git.Git) (base string, ok bool, to string, err error) {
+	to = strings.TrimSpace(o.to)
+	if to == "" {
+		to = "HEAD"
+	}
+	if _, err := gitClient.ResolveRevision(to); err != nil {
+		return "", false, "", err
+	}
+
+	if from := strings.TrimSpace(o.from); from != "" {
+		if _, err := gitClient.ResolveRevision(from); err != nil {
+			return "", false, "", err
+		}
+		return from, true, to, nil
+	}
+
+	base, ok, err = gitClient.PreviousTag(git.TagQuery{
+		Rev:            to,
+		Pattern:        o.match,
+		SkipPrerelease: o.skipPrerelease,
+	})
+	if err != nil {
+		return "", false, "", err
+	}
+	return base, ok, to, nil
+}
+
+// usePackage limits the range to a monorepo package and, unless --match is
+// given, to the package's own tags.
+func (o *tagRangeOptions) usePackage(pkg monorepo.Package) {
+	o.tagPrefix = pkg.TagPrefix
+	o.paths = []string{pkg.Dir}
+	if strings.TrimSpace(o.match) == "" {
+		o.match = pkg.TagPrefix + "*"
+	}
+}
+
+// rangeSpec returns "base..to", or just "to" for the whole history.
+func (o tagRangeOptions) rangeSpec(base string, ok bool, to string) string {
+	if !ok {
+		return to
+	}
+	return fmt.Sprintf("%s..%s", base, to)
+}
+
 func newTagCmd() *cobra.Command {
-	var versionFlag string
+	opts := tagOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "tag [version]",
 		Short: "Generate an annotated git tag message with AI and create the tag",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runTag(cmd, args, versionFlag)
+			return runTag(cmd, args, opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&versionFlag, "version", "", "tag version (if not provided as an argument)")
+	cmd.Flags().StringVar(&opts.version, "version", "", "tag version (if not provided as an argument)")
+	cmd.Flags().StringVar(&opts.bump, "bump", "", "propose the next version from the latest tag: auto, major, minor, patch or pre")
+	cmd.Flags().StringVar(&opts.preid, "preid", "rc", "prerelease identifier used by --bump pre")
+	cmd.Flags().BoolVar(&opts.changelog, "changelog", false, "also add the release to the changelog and commit it before tagging")
+	cmd.Flags().StringVar(&opts.changelogFile, "changelog-file", defaultChangelogFile, "changelog file used with --changelog")
+	cmd.Flags().StringVar(&opts.push, "push", "", "push the tag to a remote after creating it (--push=<remote>, default origin)")
+	cmd.Flags().Lookup("push").NoOptDefVal = defaultPushRemote
+	addTagRangeFlags(cmd, &opts.rng)
+	cmd.Flags().BoolVar(&opts.release, "release", false, "create a GitHub/GitLab/Gitea release from the tag message (implies --push)")
+	cmd.Flags().StringVar(&opts.pkg, "package", "", "tag a monorepo package (e.g. libs/auth) using its tag prefix and only its commits")
+	addOutputFlags(cmd)
+	addYesFlags(cmd, "create the tag with the generated message without opening the editor")
 	return cmd
 }
 
-func runTag(cmd *cobra.Command, args []string, versionFlag string) error {
-	version, err := resolveTagVersion(cmd, args, versionFlag)
-	if err != nil {
-		return err
+func runTag(cmd *cobra.Command, args []string, opts tagOptions) error {
+	redirectProgress(cmd)
+	if machineOutput() && (opts.changelog || opts.push != "" || opts.release) {
+		return fmt.Errorf("--print and --json only generate the tag message and cannot be combined with --changelog, --push or --release")
 	}
 
 	cfg, err := config.Load()
@@ -53,54 +150,187 @@ func runTag(cmd *cobra.Command, args []string, versionFlag string) error {
 	if err != nil {
 		return err
 	}
-
-	if err := ensureTagDoesNotExist(gitClient, version); err != nil {
+	gitClient.SetSigning(signOptions(cfg))
+	language, err := promptLanguage(cfg, gitClient)
+	if err != nil {
 		return err
 	}
 
-	infoBlock, hasPreviousTag, err := buildTagContext(gitClient, version)
+	if opts.pkg != "" {
+		layout, err := loadMonorepoLayout(cfg)
+		if err != nil {
+			return err
+		}
+		pkg, err := layout.Lookup(opts.pkg)
+		if err != nil {
+			return err
+		}
+		opts.rng.usePackage(pkg)
+	}
+
+	version, err := resolveTagVersion(cmd, cfg, gitClient, args, opts)
 	if err != nil {
 		return err
 	}
 
-	tagMessage, err := generateTagMessage(cfg, infoBlock)
+	if err := ensureTagDoesNotExist(gitClient, version); err != nil {
+		return err
+	}
+
+	if opts.changelog && strings.TrimSpace(opts.rng.to) != "" && strings.TrimSpace(opts.rng.to) != "HEAD" {
+		return fmt.Errorf("--changelog commits on HEAD and cannot be combined with --to")
+	}
+
+	if opts.release && opts.push == "" {
+		opts.push = defaultPushRemote
+	}
+	var releaseClient forge.Client
+	if opts.release && !dryRun {
+		releaseClient, err = newReleaseClient(cfg, gitClient, opts.push)
+		if err != nil {
+			return err
+		}
+	}
+
+	infoBlock, hasPreviousTag, err := buildTagContext(gitClient, version, opts.rng)
 	if err != nil {
 		return err
 	}
 
-	edited, aborted, err := reviewTagMessage(cmd, cfg, tagMessage)
+	provider, err := model.NewProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	tagMessage, err := generateTagMessage(cfg, provider, language, infoBlock)
 	if err != nil {
 		return err
 	}
-	if aborted {
-		return nil
+
+	if machineOutput() {
+		result := newGenerationResult(cfg, provider, tagMessage)
+		release := forge.ReleaseFromTagMessage(version, tagMessage)
+		result.Version = version
+		result.Release = &tagRelease{Name: release.Name, Body: release.Body}
+		return writeResult(result)
+	}
+
+	edited := tagMessage
+	if interactive() {
+		var aborted bool
+		edited, aborted, err = reviewTagMessage(cmd, cfg, gitClient, version, tagMessage)
+		if err != nil {
+			return err
+		}
+		if aborted {
+			return nil
+		}
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nGenerated tag message:\n%s\n", tagMessage)
+		noteNonInteractive()
+	}
+
+	changelogSection := ""
+	if opts.changelog {
+		changelogVersion := strings.TrimPrefix(version, opts.rng.tagPrefix)
+		changelogSection, err = buildChangelogSection(gitClient, cfg, changelogVersion, changelogOptions{file: opts.changelogFile, rng: opts.rng})
+		if err != nil {
+			return err
+		}
 	}
 
 	if dryRun {
+		if changelogSection != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "\nChangelog section:\n%s\n", changelogSection)
+		}
 		fmt.Fprintln(cmd.OutOrStdout(), "\nDry run mode - no tag was created")
 		return nil
 	}
 
-	if err := gitClient.CreateAnnotatedTag(version, edited); err != nil {
+	if changelogSection != "" {
+		if err := commitChangelog(gitClient, opts.changelogFile, version, changelogSection); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "\nUpdated and committed %s\n", opts.changelogFile)
+	}
+
+	if err := gitClient.CreateAnnotatedTagAt(version, edited, opts.rng.to); err != nil {
 		return fmt.Errorf("failed to create tag: %w", err)
 	}
 
 	fmt.Fprintf(cmd.OutOrStdout(), "\nTag created: %s\n", version)
+
+	if opts.push != "" {
+		if err := gitClient.PushTag(opts.push, version); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Tag pushed to %s\n", opts.push)
+	}
+	if releaseClient != nil {
+		url, err := publishRelease(releaseClient, version, opts.rng.tagPrefix, edited)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Release created: %s\n", url)
+	}
 	if !hasPreviousTag {
 		fmt.Fprintln(cmd.OutOrStdout(), "Note: no previous tag was found; consider creating an initial baseline tag for better release notes.")
 	}
 	return nil
 }
 
-func resolveTagVersion(cmd *cobra.Command, args []string, versionFlag string) (string, error) {
+// commitChangelog writes the release section and commits it so that the tag
+// created afterwards includes the updated changelog.
+func commitChangelog(gitClient *git.Git, path, version, section string) error {
+	staged, err := gitClient.HasStagedChanges()
+	if err != nil {
+		return fmt.Errorf("failed to check staged changes: %w", err)
+	}
+	if staged {
+		return fmt.Errorf("staged changes would be included in the changelog commit; commit or stash them first")
+	}
+
+	if err := writeChangelog(path, section); err != nil {
+		return err
+	}
+	if err := gitClient.Add(path); err != nil {
+		return err
+	}
+	if err := gitClient.Commit(fmt.Sprintf("docs(changelog): add %s release notes", version)); err != nil {
+		return fmt.Errorf("failed to commit changelog: %w", err)
+	}
+	return nil
+}
+
+func resolveTagVersion(cmd *cobra.Command, cfg *config.Config, gitClient *git.Git, args []string, opts tagOptions) (string, error) {
 	version := ""
 	if len(args) > 0 {
 		version = args[0]
 	}
 	if strings.TrimSpace(version) == "" {
-		version = versionFlag
+		version = opts.version
+	}
+	if opts.bump != "" {
+		if strings.TrimSpace(version) != "" {
+			return "", fmt.Errorf("--bump cannot be combined with an explicit version")
+		}
+		proposed, err := proposeNextVersion(cmd, cfg, gitClient, opts.bump, opts.preid, opts.rng)
+		if err != nil {
+			return "", err
+		}
+		version = proposed
+		if interactive() {
+			version, err = confirmVersion(cmd, proposed)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "Next version: %s\n", proposed)
+		}
 	}
 	if strings.TrimSpace(version) == "" {
+		if !interactive() {
+			return "", fmt.Errorf("a tag version is required when running non-interactively; pass it as an argument, with --version or use --bump")
+		}
 		var err error
 		version, err = promptForVersion(cmd)
 		if err != nil {
@@ -111,9 +341,84 @@ func resolveTagVersion(cmd *cobra.Command, args []string, versionFlag string) (s
 	if version == "" {
 		return "", fmt.Errorf("tag version cannot be empty")
 	}
+	if !strings.HasPrefix(version, opts.rng.tagPrefix) {
+		version = opts.rng.tagPrefix + version
+	}
 	return version, nil
 }
 
+// proposeNextVersion computes the next version from the latest tag. With
+// bump "auto" the level is inferred from the commits since then, read in the
+// configured commit format.
+func proposeNextVersion(cmd *cobra.Command, cfg *config.Config, gitClient *git.Git, bump, preid string, rng tagRangeOptions) (string, error) {
+	bump = strings.ToLower(strings.TrimSpace(bump))
+	switch bump {
+	case "auto", string(semver.Major), string(semver.Minor), string(semver.Patch), string(semver.Pre):
+	default:
+		return "", fmt.Errorf("invalid --bump value %q (expected auto, major, minor, patch or pre)", bump)
+	}
+
+	latest, ok, to, err := rng.resolve(gitClient)
+	if err != nil {
+		return "", err
+	}
+
+	current := semver.Version{Prefix: "v"}
+	rangeSpec := rng.rangeSpec(latest, ok, to)
+	if ok {
+		current, err = semver.Parse(strings.TrimPrefix(latest, rng.tagPrefix))
+		if err != nil {
+			return "", fmt.Errorf("latest tag %s is not a semantic version; pass the version explicitly", latest)
+		}
+	}
+
+	level := semver.Level(bump)
+	if bump == "auto" {
+		level = semver.Minor
+		if ok {
+			messages, _, err := gitClient.CommitMessages(rangeSpec, 0, rng.paths...)
+			if err != nil {
+				return "", fmt.Errorf("failed to get commit messages: %w", err)
+			}
+			if len(messages) == 0 {
+				return "", fmt.Errorf("no commits since %s", latest)
+			}
+			format, err := commitFormat(cfg, gitClient)
+			if err != nil {
+				return "", err
+			}
+			level = semver.LevelForCommits(current, format, messages)
+		}
+	}
+
+	next, err := current.Bump(level, preid)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		fmt.Fprintf(cmd.OutOrStdout(), "Latest tag: %s (bump: %s)\n", latest, level)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "No previous tag found (bump: %s)\n", level)
+	}
+	return rng.tagPrefix + next.String(), nil
+}
+
+// confirmVersion lets the user accept the proposed version with Enter or type
+// a different one.
+func confirmVersion(cmd *cobra.Command, proposed string) (string, error) {
+	fmt.Fprintf(cmd.OutOrStdout(), "Next version [%s]: ", proposed)
+	reader := bufio.NewReader(cmd.InOrStdin())
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read tag version: %w", err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return proposed, nil
+}
+
 func mustOpenRepo() (*git.Git, error) {
 	if err := validator.ValidateRepository("."); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -138,30 +443,30 @@ func ensureTagDoesNotExist(gitClient *git.Git, tag string) error {
 	return nil
 }
 
-func buildTagContext(gitClient *git.Git, version string) (infoBlock string, hasPreviousTag bool, err error) {
-	previousTag, hasPreviousTag, err := gitClient.LatestTag()
+func buildTagContext(gitClient *git.Git, version string, rng tagRangeOptions) (infoBlock string, hasPreviousTag bool, err error) {
+	previousTag, hasPreviousTag, to, err := rng.resolve(gitClient)
 	if err != nil {
 		return "", false, err
 	}
 
-	rangeSpec := ""
-	if hasPreviousTag {
-		rangeSpec = fmt.Sprintf("%s..HEAD", previousTag)
-	}
+	rangeSpec := rng.rangeSpec(previousTag, hasPreviousTag, to)
 
-	commitSubjects, truncated, err := gitClient.CommitSubjects(rangeSpec, defaultTagCommitLimit)
+	commits, truncated, err := gitClient.CommitLog(rangeSpec, defaultTagCommitLimit, rng.paths...)
 	if err != nil {
-		return "", false, fmt.Errorf("failed to get commit subjects: %w", err)
+		return "", false, fmt.Errorf("failed to get commit log: %w", err)
 	}
 
 	diffStat := "unavailable (no previous tag found)"
 	nameStatus := "unavailable (no previous tag found)"
 	if hasPreviousTag {
-		diffStat = formatOrUnavailable(func() (string, error) { return gitClient.DiffStat(rangeSpec) }, defaultTagDiffStatMaxLen)
-		nameStatus = formatOrUnavailable(func() (string, error) { return gitClient.DiffNameStatus(rangeSpec) }, defaultTagNameStatusMaxLen)
+		diffStat = formatOrUnavailable(func() (string, error) { return gitClient.DiffStat(rangeSpec, rng.paths...) }, defaultTagDiffStatMaxLen)
+		nameStatus = formatOrUnavailable(func() (string, error) { return gitClient.DiffNameStatus(rangeSpec, rng.paths...) }, defaultTagNameStatusMaxLen)
 	}
 
-	infoBlock = buildTagInfoBlock(version, previousTag, hasPreviousTag, rangeSpec, commitSubjects, truncated, diffStat, nameStatus)
+	infoBlock = buildTagInfoBlock(version, previousTag, hasPreviousTag, rangeSpec, commits, truncated, diffStat, nameStatus)
+	if len(rng.paths) > 0 {
+		infoBlock = fmt.Sprintf("Package: %s (only commits touching this path are listed)\n", strings.Join(rng.paths, ", ")) + infoBlock
+	}
 	return infoBlock, hasPreviousTag, nil
 }
 
@@ -173,23 +478,16 @@ func formatOrUnavailable(fetch func() (string, error), maxLen int) string {
 	return truncateText(strings.TrimSpace(s), maxLen)
 }
 
-func generateTagMessage(cfg *config.Config, infoBlock string) (string, error) {
-	provider, err := model.NewProvider(cfg)
-	if err != nil {
-		return "", fmt.Errorf("failed to create provider: %w", err)
-	}
-	provider.SetTemplate(prompt.NewTagTemplate())
-
-	modelName := cfg.Model
-	if cfg.Provider == "custom" && cfg.Custom.Model != "" {
-		modelName = cfg.Custom.Model
-	}
+func generateTagMessage(cfg *config.Config, provider model.Provider, language prompt.Language, infoBlock string) (string, error) {
+	template := prompt.NewTagTemplate()
+	template.SetLanguage(language)
+	provider.SetTemplate(template)
 
-	fmt.Printf("Generating tag message using %s with model %s...\n", provider.Name(), modelName)
+	fmt.Fprintf(progress, "Generating tag message using %s with model %s...\n", provider.Name(), resolveModelName(cfg))
 
 	tagMessage, err := provider.GenerateMessage(context.Background(), infoBlock)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate tag message: %w", err)
+		return "", withExitCode(exitProviderError, fmt.Errorf("failed to generate tag message: %w", err))
 	}
 
 	tagMessage = prompt.CleanAIText(tagMessage)
@@ -200,16 +498,32 @@ func generateTagMessage(cfg *config.Config, infoBlock string) (string, error) {
 	return tagMessage, nil
 }
 
-func reviewTagMessage(cmd *cobra.Command, cfg *config.Config, tagMessage string) (edited string, aborted bool, err error) {
+func reviewTagMessage(cmd *cobra.Command, cfg *config.Config, gitClient *git.Git, version, tagMessage string) (edited string, aborted bool, err error) {
 	fmt.Fprintf(cmd.OutOrStdout(), "\nGenerated tag message:\n%s\n", tagMessage)
 
+	commentChar := editor.ResolveCommentChar(gitClient.CommentChar(), tagMessage)
+	tpl := editor.Template{
+		Message:     tagMessage,
+		CommentChar: commentChar,
+		Sections: [][]string{{
+			"Write a message for tag:",
+			"  " + version,
+			fmt.Sprintf("Lines starting with '%s' will be ignored.", commentChar),
+		}},
+	}
+
+	editorCmd, err := resolveEditor(cfg, gitClient)
+	if err != nil {
+		return "", false, err
+	}
+
 	fmt.Fprintln(cmd.OutOrStdout(), "\nOpening editor to review/edit tag message...")
-	edited, err = editor.Open(tagMessage, cfg.Editor)
+	edited, err = editor.Open(tpl.Render(), editorCmd)
 	if err != nil {
 		return "", false, fmt.Errorf("failed to open editor: %w", err)
 	}
 
-	edited = strings.TrimSpace(edited)
+	edited = editor.Cleanup(edited, commentChar)
 	if edited == "" {
 		fmt.Fprintln(cmd.OutOrStdout(), "\nTag message is empty, aborting tag creation.")
 		return "", true, nil
@@ -233,7 +547,7 @@ func buildTagInfoBlock(
 	previousTag string,
 	hasPreviousTag bool,
 	rangeSpec string,
-	commitSubjects []string,
+	commits []git.CommitInfo,
 	truncated bool,
 	diffStat string,
 	nameStatus string,
@@ -255,22 +569,21 @@ func buildTagInfoBlock(
 		b.WriteString("Range: (no previous tag; summary is for repository history up to HEAD)\n")
 	}
 
-	b.WriteString("\nCommit subjects:\n")
-	if len(commitSubjects) == 0 {
+	b.WriteString("\nCommits (newest first):\n")
+	if len(commits) == 0 {
 		b.WriteString("(none)\n")
 	} else {
-		for _, s := range commitSubjects {
-			s = strings.TrimSpace(s)
-			if s == "" {
-				continue
-			}
-			b.WriteString("- ")
-			b.WriteString(s)
-			b.WriteString("\n")
-		}
+		writeTagCommits(&b, commits, defaultTagCommitBodiesMaxLen)
 		if truncated {
 			b.WriteString("(commit list truncated)\n")
 		}
+
+		b.WriteString("\nContributors:\n")
+		for _, c := range tagContributors(commits) {
+			b.WriteString("- ")
+			b.WriteString(c)
+			b.WriteString("\n")
+		}
 	}
 
 	b.WriteString("\nDiffstat:\n")
@@ -284,6 +597,84 @@ func buildTagInfoBlock(
 	return b.String()
 }
 
+// writeTagCommits writes one line per commit with its short hash, author and
+// pull request references, followed by the commit body while budget allows.
+func writeTagCommits(b *strings.Builder, commits []git.CommitInfo, budget int) {
+	omitted := false
+	for _, c := range commits {
+		subject := strings.TrimSpace(c.Subject)
+		if subject == "" {
+			continue
+		}
+
+		meta := []string{c.ShortHash, c.Author}
+		for _, pr := range c.PullRequests {
+			meta = append(meta, fmt.Sprintf("#%d", pr))
+		}
+		fmt.Fprintf(b, "- %s [%s]\n", subject, strings.Join(meta, ", "))
+
+		body := strings.TrimSpace(c.Body)
+		if body == "" {
+			continue
+		}
+		if len(body) > budget {
+			omitted = true
+			continue
+		}
+		budget -= len(body)
+		for _, line := range strings.Split(body, "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			b.WriteString("    ")
+			b.WriteString(strings.TrimRight(line, " \t"))
+			b.WriteString("\n")
+		}
+	}
+	if omitted {
+		b.WriteString("(some commit bodies omitted to fit the size limit)\n")
+	}
+}
+
+// tagContributors lists commit authors and Co-authored-by trailers, most
+// active first.
+func tagContributors(commits []git.CommitInfo) []string {
+	counts := make(map[string]int)
+	var order []string
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		if _, ok := counts[name]; !ok {
+			order = append(order, name)
+		}
+		counts[name]++
+	}
+
+	for _, c := range commits {
+		add(c.Author)
+		for _, t := range c.Trailers {
+			if strings.EqualFold(t.Key, "Co-authored-by") {
+				name, _, _ := strings.Cut(t.Value, "<")
+				add(name)
+			}
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return counts[order[i]] > counts[order[j]] })
+
+	contributors := make([]string, 0, len(order))
+	for _, name := range order {
+		if counts[name] == 1 {
+			contributors = append(contributors, fmt.Sprintf("%s (1 commit)", name))
+			continue
+		}
+		contributors = append(contributors, fmt.Sprintf("%s (%d commits)", name, counts[name]))
+	}
+	return contributors
+}
+
 func truncateText(s string, max int) string {
 	if max <= 0 || len(s) <= max {
 		return s
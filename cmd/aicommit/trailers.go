@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aicommit/aicommit/internal/config"
+	"github.com/aicommit/aicommit/internal/git"
+)
+
+// maxSuggestedCoAuthors bounds the co-author suggestions printed for the
+// staged files.
+const maxSuggestedCoAuthors = 3
+
+// commitTrailers are the Co-authored-by and Signed-off-by trailers added to
+// the generated message.
+type commitTrailers struct {
+	trailers  []git.Trailer
+	gitClient *git.Git
+}
+
+// buildCommitTrailers resolves --co-author values (aliases from co_authors or
+// literal "Name <email>") and the sign-off identity.
+func buildCommitTrailers(cfg *config.Config, gitClient *git.Git, signoff bool, coAuthors []string) (commitTrailers, error) {
+	t := commitTrailers{gitClient: gitClient}
+	for _, c := range coAuthors {
+		author, err := resolveCoAuthor(cfg, c)
+		if err != nil {
+			return commitTrailers{}, err
+		}
+		t.trailers = append(t.trailers, git.Trailer{Key: "Co-authored-by", Value: author})
+	}
+
+	if signoff || cfg.Signoff {
+		ident, err := gitClient.Identity()
+		if err != nil {
+			return commitTrailers{}, err
+		}
+		t.trailers = append(t.trailers, git.Trailer{Key: "Signed-off-by", Value: ident})
+	}
+	return t, nil
+}
+
+func resolveCoAuthor(cfg *config.Config, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "<") && strings.HasSuffix(value, ">") {
+		return value, nil
+	}
+	if author, ok := cfg.CoAuthors[strings.ToLower(value)]; ok && strings.TrimSpace(author) != "" {
+		return strings.TrimSpace(author), nil
+	}
+	return "", fmt.Errorf("unknown co-author %q: add it to co_authors in the config or pass \"Name <email>\"", value)
+}
+
+// apply appends the trailers after the body; trailers already present are
+// not duplicated.
+func (t commitTrailers) apply(message string) (string, error) {
+	return t.gitClient.AddTrailers(message, t.trailers)
+}
+
+// suggestCoAuthors prints the recent authors of files, other than the
+// current user and the co-authors already added, as --co-author hints.
+func suggestCoAuthors(cfg *config.Config, gitClient *git.Git, files []string, t commitTrailers) {
+	authors, err := gitClient.RecentAuthors(files, maxSuggestedCoAuthors+len(t.trailers)+1)
+	if err != nil || len(authors) == 0 {
+		return
+	}
+
+	skip := make(map[string]bool)
+	if ident, err := gitClient.Identity(); err == nil {
+		skip[ident] = true
+	}
+	for _, tr := range t.trailers {
+		skip[tr.Value] = true
+	}
+
+	aliases := make(map[string]string, len(cfg.CoAuthors))
+	for alias, author := range cfg.CoAuthors {
+		aliases[strings.TrimSpace(author)] = alias
+	}
+
+	var hints []string
+	for _, a := range authors {
+		if skip[a] || len(hints) == maxSuggestedCoAuthors {
+			continue
+		}
+		if alias, ok := aliases[a]; ok {
+			hints = append(hints, fmt.Sprintf("--co-author %s (%s)", alias, a))
+		} else {
+			hints = append(hints, fmt.Sprintf("--co-author '%s'", a))
+		}
+	}
+	if len(hints) == 0 {
+		return
+	}
+
+	fmt.Fprintln(progress, "Recent authors of the staged files, if you paired:")
+	for _, h := range hints {
+		fmt.Fprintf(progress, "  %s\n", h)
+	}
+}
@@ -14,6 +14,65 @@ type Config struct {
 	Provider string            `mapstructure:"provider"`
 	Editor   string            `mapstructure:"editor"`
 	Custom   CustomConfig      `mapstructure:"custom"`
+	PR       PRConfig          `mapstructure:"pr"`
+	Review   ReviewConfig      `mapstructure:"review"`
+	Signing  SigningConfig     `mapstructure:"signing"`
+	Release  ReleaseConfig     `mapstructure:"release"`
+	Monorepo MonorepoConfig    `mapstructure:"monorepo"`
+	Rules    RulesConfig       `mapstructure:"rules"`
+	Issues   IssuesConfig      `mapstructure:"issues"`
+	// HistoryExamples is the number of recent commit messages from the
+	// repository used as few-shot examples; 0 disables them.
+	HistoryExamples int `mapstructure:"history_examples"`
+	// StructuredOutput asks providers for the commit message as JSON fields
+	// and assembles the message from them.
+	StructuredOutput bool `mapstructure:"structured_output"`
+	// Format is the commit message convention: conventional (default),
+	// angular, gitmoji, conventional+gitmoji or free-form.
+	Format string `mapstructure:"format"`
+	// Language is the language of generated descriptions, bodies and release
+	// notes, e.g. "de" or "zh"; the commit type keyword stays in English.
+	Language string `mapstructure:"language"`
+	// Signoff adds a Signed-off-by trailer to every commit (same as --signoff).
+	Signoff bool `mapstructure:"signoff"`
+	// CoAuthors maps aliases for --co-author to "Name <email>".
+	CoAuthors map[string]string `mapstructure:"co_authors"`
+}
+
+// IssuesConfig extracts issue keys from the current branch name and adds
+// them to commit messages.
+type IssuesConfig struct {
+	// Mode is "prompt" (ask the model to reference them), "trailer" (append
+	// a trailer) or "subject" (prefix the description); empty disables it.
+	Mode string `mapstructure:"mode"`
+	// Patterns are regular expressions; the first capture group, or the
+	// whole match, is the key. Default: Jira-style keys such as PROJ-1234.
+	Patterns []string `mapstructure:"patterns"`
+	// Trailer is the trailer token used in "trailer" mode (default Refs).
+	Trailer string `mapstructure:"trailer"`
+}
+
+// RulesConfig configures the commit message conventions checked for
+// generated and edited messages. Zero values disable a rule.
+type RulesConfig struct {
+	Types               []string `mapstructure:"types"`
+	Scopes              []string `mapstructure:"scopes"`
+	ScopeRequired       bool     `mapstructure:"scope_required"`
+	HeaderMaxLength     int      `mapstructure:"header_max_length"`
+	SubjectCase         string   `mapstructure:"subject_case"`
+	SubjectNoPeriod     bool     `mapstructure:"subject_no_period"`
+	BodyMaxLineLength   int      `mapstructure:"body_max_line_length"`
+	RequiredTrailers    []string `mapstructure:"required_trailers"`
+	ForbiddenTrailers   []string `mapstructure:"forbidden_trailers"`
+	BreakingConsistency bool     `mapstructure:"breaking_consistency"`
+	// Severity overrides rule severities by rule name: error, warning or off.
+	Severity map[string]string `mapstructure:"severity"`
+	// RepairAttempts bounds the follow-up model requests for generated
+	// messages that still violate error-level rules after automatic fixes.
+	RepairAttempts int `mapstructure:"repair_attempts"`
+	// Commitlint is the path of a commitlint config whose rules are applied
+	// on top of these; empty searches the repository root, "off" disables it.
+	Commitlint string `mapstructure:"commitlint"`
 }
 
 type CustomConfig struct {
@@ -22,6 +81,56 @@ type CustomConfig struct {
 	Model  string `mapstructure:"model"`
 }
 
+// PRConfig configures `aicommit pr`.
+type PRConfig struct {
+	// Template is the path to a Markdown skeleton for the pull request body.
+	// The built-in Summary/Changes/Testing/Risk layout is used when empty.
+	Template string `mapstructure:"template"`
+}
+
+// ReviewConfig configures `aicommit review` and `aicommit --review`.
+type ReviewConfig struct {
+	// FailOn is the lowest finding severity that fails the review: info,
+	// warning, error (default) or off.
+	FailOn string `mapstructure:"fail_on"`
+	// Output is "table" (default) or "line" for file:line: message output.
+	Output string `mapstructure:"output"`
+}
+
+// SigningConfig configures commit and tag signing. Empty Key and Format fall
+// back to git's user.signingkey and gpg.format.
+type SigningConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Key     string `mapstructure:"key"`
+	Format  string `mapstructure:"format"`
+}
+
+// ReleaseConfig configures hosting releases created by `aicommit tag --release`.
+type ReleaseConfig struct {
+	// Forge is github, gitlab or gitea; detected from the remote URL when empty.
+	Forge string `mapstructure:"forge"`
+	// BaseURL is the REST API base URL, e.g. https://gitea.example.com/api/v1.
+	BaseURL string `mapstructure:"base_url"`
+	Token   string `mapstructure:"token"`
+}
+
+// MonorepoConfig maps package directories to Conventional Commit scopes and
+// tag prefixes.
+type MonorepoConfig struct {
+	// EnforceScope rejects commit messages whose scope does not match the
+	// packages touched by the staged changes.
+	EnforceScope bool              `mapstructure:"enforce_scope"`
+	Packages     []MonorepoPackage `mapstructure:"packages"`
+}
+
+// MonorepoPackage describes the packages matched by a directory glob. Scope
+// and TagPrefix may use {name} (last path segment) and {dir} (matched directory).
+type MonorepoPackage struct {
+	Path      string `mapstructure:"path"`
+	Scope     string `mapstructure:"scope"`
+	TagPrefix string `mapstructure:"tag_prefix"`
+}
+
 func Load() (*Config, error) {
 	viper.SetConfigName("aicommit")
 	viper.SetConfigType("yaml")
@@ -44,6 +153,14 @@ func Load() (*Config, error) {
 		"deepseek": "",
 	})
 
+	viper.SetDefault("rules.header_max_length", 100)
+	viper.SetDefault("rules.subject_no_period", true)
+	viper.SetDefault("rules.body_max_line_length", 100)
+	viper.SetDefault("rules.breaking_consistency", true)
+	viper.SetDefault("rules.repair_attempts", 2)
+	viper.SetDefault("review.fail_on", "error")
+	viper.SetDefault("review.output", "table")
+
 	viper.SetEnvPrefix("AICOMMIT")
 	viper.AutomaticEnv()
 
@@ -75,3 +192,24 @@ func (c *Config) GetAPIKey(provider string) string {
 	}
 	return ""
 }
+
+// GetReleaseToken returns the API token for forge. AICOMMIT_RELEASE_TOKEN and
+// the forge's conventional variables (GITHUB_TOKEN, GH_TOKEN, GITLAB_TOKEN,
+// GITEA_TOKEN) take precedence over the config file.
+func (c *Config) GetReleaseToken(forge string) string {
+	envKeys := []string{"AICOMMIT_RELEASE_TOKEN"}
+	switch forge {
+	case "github":
+		envKeys = append(envKeys, "GITHUB_TOKEN", "GH_TOKEN")
+	case "gitlab":
+		envKeys = append(envKeys, "GITLAB_TOKEN")
+	case "gitea":
+		envKeys = append(envKeys, "GITEA_TOKEN")
+	}
+	for _, key := range envKeys {
+		if val := os.Getenv(key); val != "" {
+			return val
+		}
+	}
+	return c.Release.Token
+}
@@ -0,0 +1,182 @@
+// Package forge creates releases on code hosting services (GitHub, GitLab, Gitea).
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Release describes a hosting release for an existing tag.
+type Release struct {
+	Tag        string
+	Name       string
+	Body       string
+	Prerelease bool
+}
+
+// Client creates releases on a forge.
+type Client interface {
+	// CreateRelease creates the release and returns its web URL.
+	CreateRelease(ctx context.Context, release Release) (string, error)
+	Name() string
+}
+
+// Repository identifies a repository on a forge host.
+type Repository struct {
+	Host string
+	// Path is "owner/name"; GitLab allows nested groups ("group/sub/name").
+	Path string
+}
+
+// Owner returns the first path segment.
+func (r Repository) Owner() string {
+	return strings.SplitN(r.Path, "/", 2)[0]
+}
+
+// Name returns the last path segment.
+func (r Repository) Name() string {
+	return r.Path[strings.LastIndex(r.Path, "/")+1:]
+}
+
+// ParseRemoteURL parses https, ssh:// and scp-style (git@host:owner/repo.git)
+// remote URLs.
+func ParseRemoteURL(remote string) (Repository, error) {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return Repository{}, fmt.Errorf("remote URL is empty")
+	}
+
+	var host, path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return Repository{}, fmt.Errorf("invalid remote URL %q: %w", remote, err)
+		}
+		host = u.Hostname()
+		path = u.Path
+	} else if at := strings.Index(remote, "@"); at >= 0 && strings.Contains(remote[at:], ":") {
+		rest := remote[at+1:]
+		colon := strings.Index(rest, ":")
+		host = rest[:colon]
+		path = rest[colon+1:]
+	} else {
+		return Repository{}, fmt.Errorf("unsupported remote URL %q", remote)
+	}
+
+	path = strings.Trim(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	if host == "" || !strings.Contains(path, "/") {
+		return Repository{}, fmt.Errorf("cannot determine repository from remote URL %q", remote)
+	}
+
+	return Repository{Host: strings.ToLower(host), Path: path}, nil
+}
+
+// DetectKind guesses the forge type from the repository host.
+func DetectKind(host string) (string, error) {
+	host = strings.ToLower(host)
+	switch {
+	case host == "github.com" || strings.Contains(host, "github"):
+		return "github", nil
+	case strings.Contains(host, "gitlab"):
+		return "gitlab", nil
+	case host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return "gitea", nil
+	default:
+		return "", fmt.Errorf("cannot detect forge type for host %s; set release.forge to github, gitlab or gitea", host)
+	}
+}
+
+// NewClient creates a client for kind (github, gitlab or gitea; detected from
+// the remote when empty). An empty baseURL selects the forge's public API
+// for the remote host.
+func NewClient(kind, baseURL, token, remoteURL string) (Client, error) {
+	repo, err := ParseRemoteURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind, err = DetectKind(repo.Host)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.TrimSpace(token) == "" {
+		return nil, fmt.Errorf("%s release token is required", kind)
+	}
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+
+	switch kind {
+	case "github":
+		if baseURL == "" {
+			baseURL = "https://api.github.com"
+			if repo.Host != "github.com" {
+				baseURL = fmt.Sprintf("https://%s/api/v3", repo.Host)
+			}
+		}
+		return NewGitHubClient(baseURL, token, repo), nil
+	case "gitlab":
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("https://%s/api/v4", repo.Host)
+		}
+		return NewGitLabClient(baseURL, token, repo), nil
+	case "gitea":
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("https://%s/api/v1", repo.Host)
+		}
+		return NewGiteaClient(baseURL, token, repo), nil
+	default:
+		return nil, fmt.Errorf("unsupported forge: %s", kind)
+	}
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 30 * time.Second}
+}
+
+// postJSON sends payload to endpoint and decodes a 2xx JSON response into out.
+func postJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, payload, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w, body: %s", err, string(responseBody))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   Repository
+	}{
+		{"https://github.com/lemon956/aicommit.git", Repository{Host: "github.com", Path: "lemon956/aicommit"}},
+		{"git@github.com:lemon956/aicommit.git", Repository{Host: "github.com", Path: "lemon956/aicommit"}},
+		{"ssh://git@gitlab.example.com:2222/group/sub/project.git", Repository{Host: "gitlab.example.com", Path: "group/sub/project"}},
+		{"https://codeberg.org/owner/repo/", Repository{Host: "codeberg.org", Path: "owner/repo"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRemoteURL(tt.remote)
+		require.NoError(t, err, tt.remote)
+		assert.Equal(t, tt.want, got)
+	}
+
+	for _, invalid := range []string{"", "/srv/git/repo.git", "https://github.com/onlyowner"} {
+		_, err := ParseRemoteURL(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	repo := Repository{Host: "gitlab.com", Path: "group/sub/project"}
+	assert.Equal(t, "group", repo.Owner())
+	assert.Equal(t, "project", repo.Name())
+}
+
+func TestNewClient(t *testing.T) {
+	c, err := NewClient("", "", "token", "git@github.com:o/r.git")
+	require.NoError(t, err)
+	assert.Equal(t, "github", c.Name())
+	assert.Equal(t, "https://api.github.com", c.(*GitHubClient).baseURL)
+
+	c, err = NewClient("", "", "token", "https://gitlab.example.com/g/r.git")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example.com/api/v4", c.(*GitLabClient).baseURL)
+
+	c, err = NewClient("gitea", "https://git.internal/api/v1/", "token", "https://git.internal/o/r.git")
+	require.NoError(t, err)
+	assert.Equal(t, "https://git.internal/api/v1", c.(*GiteaClient).baseURL)
+
+	_, err = NewClient("", "", "token", "https://git.internal/o/r.git")
+	assert.Error(t, err, "unknown host needs an explicit forge")
+
+	_, err = NewClient("github", "", "", "https://github.com/o/r.git")
+	assert.Error(t, err, "token is required")
+
+	_, err = NewClient("bitbucket", "", "token", "https://github.com/o/r.git")
+	assert.Error(t, err)
+}
+
+func TestClients_CreateRelease(t *testing.T) {
+	tests := []struct {
+		kind       string
+		wantPath   string
+		authHeader string
+		authValue  string
+		response   string
+		wantURL    string
+		bodyField  string
+	}{
+		{"github", "/repos/o/r/releases", "Authorization", "Bearer secret", `{"html_url":"https://github.com/o/r/releases/tag/v1.0.0"}`, "https://github.com/o/r/releases/tag/v1.0.0", "body"},
+		{"gitlab", "/projects/g%2Fsub%2Fr/releases", "PRIVATE-TOKEN", "secret", `{"_links":{"self":"https://gitlab.com/g/sub/r/-/releases/v1.0.0"}}`, "https://gitlab.com/g/sub/r/-/releases/v1.0.0", "description"},
+		{"gitea", "/repos/o/r/releases", "Authorization", "token secret", `{"html_url":"https://gitea.local/o/r/releases/tag/v1.0.0"}`, "https://gitea.local/o/r/releases/tag/v1.0.0", "body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			var got map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, tt.wantPath, r.URL.EscapedPath())
+				assert.Equal(t, tt.authValue, r.Header.Get(tt.authHeader))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			remote := "https://example.com/o/r.git"
+			if tt.kind == "gitlab" {
+				remote = "https://example.com/g/sub/r.git"
+			}
+			c, err := NewClient(tt.kind, server.URL, "secret", remote)
+			require.NoError(t, err)
+
+			url, err := c.CreateRelease(context.Background(), Release{Tag: "v1.0.0", Name: "Release v1.0.0", Body: "### Added\n- x"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURL, url)
+			assert.Equal(t, "v1.0.0", got["tag_name"])
+			assert.Equal(t, "Release v1.0.0", got["name"])
+			assert.Equal(t, "### Added\n- x", got[tt.bodyField])
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient("github", server.URL, "secret", "https://github.com/o/r.git")
+	require.NoError(t, err)
+	_, err = c.CreateRelease(context.Background(), Release{Tag: "v1.0.0"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "422")
+	assert.Contains(t, err.Error(), "Validation Failed")
+}
+
+func TestReleaseFromTagMessage(t *testing.T) {
+	message := "Release v1.2.0\n\nAdded\n- New login flow\n* Dark mode\n\nBreaking Changes:\n• Drop Go 1.20\n\nThanks to all contributors."
+
+	r := ReleaseFromTagMessage("v1.2.0", message)
+	assert.Equal(t, "v1.2.0", r.Tag)
+	assert.Equal(t, "Release v1.2.0", r.Name)
+	assert.Equal(t, "### Added\n- New login flow\n- Dark mode\n\n### Breaking Changes\n- Drop Go 1.20\n\nThanks to all contributors.", r.Body)
+
+	r = ReleaseFromTagMessage("v2.0.0", "")
+	assert.Equal(t, "v2.0.0", r.Name)
+	assert.Equal(t, "", r.Body)
+}
@@ -0,0 +1,58 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GiteaClient creates releases through the Gitea (and Forgejo) REST API.
+type GiteaClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+	repo    Repository
+}
+
+type giteaReleaseRequest struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+type giteaReleaseResponse struct {
+	HTMLURL string `json:"html_url"`
+}
+
+func NewGiteaClient(baseURL, token string, repo Repository) *GiteaClient {
+	return &GiteaClient{
+		client:  newHTTPClient(),
+		baseURL: baseURL,
+		token:   token,
+		repo:    repo,
+	}
+}
+
+func (g *GiteaClient) CreateRelease(ctx context.Context, release Release) (string, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases", g.baseURL, g.repo.Owner(), g.repo.Name())
+	headers := map[string]string{
+		"Authorization": "token " + g.token,
+	}
+
+	var response giteaReleaseResponse
+	err := postJSON(ctx, g.client, endpoint, headers, giteaReleaseRequest{
+		TagName:    release.Tag,
+		Name:       release.Name,
+		Body:       release.Body,
+		Prerelease: release.Prerelease,
+	}, &response)
+	if err != nil {
+		return "", fmt.Errorf("gitea: failed to create release: %w", err)
+	}
+	return response.HTMLURL, nil
+}
+
+func (g *GiteaClient) Name() string {
+	return "gitea"
+}
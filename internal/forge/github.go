@@ -0,0 +1,60 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GitHubClient creates releases through the GitHub REST API.
+type GitHubClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+	repo    Repository
+}
+
+type gitHubReleaseRequest struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+type gitHubReleaseResponse struct {
+	HTMLURL string `json:"html_url"`
+}
+
+func NewGitHubClient(baseURL, token string, repo Repository) *GitHubClient {
+	return &GitHubClient{
+		client:  newHTTPClient(),
+		baseURL: baseURL,
+		token:   token,
+		repo:    repo,
+	}
+}
+
+func (g *GitHubClient) CreateRelease(ctx context.Context, release Release) (string, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases", g.baseURL, g.repo.Owner(), g.repo.Name())
+	headers := map[string]string{
+		"Authorization":        "Bearer " + g.token,
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+
+	var response gitHubReleaseResponse
+	err := postJSON(ctx, g.client, endpoint, headers, gitHubReleaseRequest{
+		TagName:    release.Tag,
+		Name:       release.Name,
+		Body:       release.Body,
+		Prerelease: release.Prerelease,
+	}, &response)
+	if err != nil {
+		return "", fmt.Errorf("github: failed to create release: %w", err)
+	}
+	return response.HTMLURL, nil
+}
+
+func (g *GitHubClient) Name() string {
+	return "github"
+}
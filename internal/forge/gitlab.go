@@ -0,0 +1,61 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GitLabClient creates releases through the GitLab REST API (v4).
+type GitLabClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+	repo    Repository
+}
+
+type gitLabReleaseRequest struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type gitLabReleaseResponse struct {
+	Links struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+func NewGitLabClient(baseURL, token string, repo Repository) *GitLabClient {
+	return &GitLabClient{
+		client:  newHTTPClient(),
+		baseURL: baseURL,
+		token:   token,
+		repo:    repo,
+	}
+}
+
+// CreateRelease creates the release. GitLab derives "upcoming release" state
+// from the release date, so Release.Prerelease is not sent.
+func (g *GitLabClient) CreateRelease(ctx context.Context, release Release) (string, error) {
+	endpoint := fmt.Sprintf("%s/projects/%s/releases", g.baseURL, url.PathEscape(g.repo.Path))
+	headers := map[string]string{
+		"PRIVATE-TOKEN": g.token,
+	}
+
+	var response gitLabReleaseResponse
+	err := postJSON(ctx, g.client, endpoint, headers, gitLabReleaseRequest{
+		TagName:     release.Tag,
+		Name:        release.Name,
+		Description: release.Body,
+	}, &response)
+	if err != nil {
+		return "", fmt.Errorf("gitlab: failed to create release: %w", err)
+	}
+	return response.Links.Self, nil
+}
+
+func (g *GitLabClient) Name() string {
+	return "gitlab"
+}
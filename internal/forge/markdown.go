@@ -0,0 +1,80 @@
+package forge
+
+import (
+	"strings"
+)
+
+// ReleaseFromTagMessage converts a plain-text annotated tag message
+// ("Release v1.2.3", then sections such as "Added" followed by bullets)
+// into a release whose name is the first line and whose body is Markdown.
+func ReleaseFromTagMessage(tag, message string) Release {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	lines := strings.Split(message, "\n")
+
+	name := strings.TrimSpace(lines[0])
+	if name == "" {
+		name = tag
+	}
+
+	return Release{
+		Tag:  tag,
+		Name: name,
+		Body: tagBodyToMarkdown(lines[1:]),
+	}
+}
+
+func tagBodyToMarkdown(lines []string) string {
+	var out []string
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			out = append(out, "")
+		case isBullet(trimmed):
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			text, _ := bulletText(trimmed)
+			out = append(out, indent+"- "+text)
+		case strings.HasPrefix(trimmed, "#"):
+			out = append(out, trimmed)
+		case isSectionHeading(trimmed, lines[i+1:]):
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+			out = append(out, "### "+strings.TrimSuffix(trimmed, ":"))
+		default:
+			out = append(out, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+func isBullet(line string) bool {
+	_, ok := bulletText(line)
+	return ok
+}
+
+// bulletText returns the text of a "-", "*" or "•" list item.
+func bulletText(line string) (string, bool) {
+	for _, marker := range []string{"- ", "* ", "• "} {
+		if strings.HasPrefix(line, marker) {
+			return strings.TrimSpace(line[len(marker):]), true
+		}
+	}
+	return "", false
+}
+
+// isSectionHeading treats a short line directly followed by a bullet list as
+// a section heading ("Added", "Breaking Changes:").
+func isSectionHeading(line string, rest []string) bool {
+	if len(line) > 40 || strings.HasSuffix(line, ".") {
+		return false
+	}
+	for _, next := range rest {
+		next = strings.TrimSpace(next)
+		if next == "" {
+			continue
+		}
+		return isBullet(next)
+	}
+	return false
+}
@@ -2,14 +2,29 @@ package git
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+// maxDiffBytes caps the size of diffs handed to the model (256KiB).
+const maxDiffBytes = 256 * 1024
+
+// ErrNoStagedChanges is returned by GetDiff when there is nothing to commit.
+var ErrNoStagedChanges = errors.New("no staged changes found")
+
 type Git struct {
-	workDir string
+	workDir     string
+	signing     SignOptions
+	commitPaths []string
+	// indexFile is the temporary index of StageOnCommit.
+	indexFile string
+	pending   pendingStage
 }
 
 func New(workDir string) *Git {
@@ -44,9 +59,20 @@ func validateTagName(tag string) error {
 }
 
 func (g *Git) runGit(args ...string) (string, error) {
+	return g.runGitInput("", args...)
+}
+
+// runGitInput runs git with input on stdin.
+func (g *Git) runGitInput(input string, args ...string) (string, error) {
 	// #nosec G204 -- We execute the git binary with explicit arguments (no shell).
 	cmd := exec.Command("git", args...)
 	cmd.Dir = g.workDir
+	if g.indexFile != "" {
+		cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+g.indexFile)
+	}
+	if input != "" {
+		cmd.Stdin = strings.NewReader(input)
+	}
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -67,25 +93,39 @@ func (g *Git) runGit(args ...string) (string, error) {
 	return stdout.String(), nil
 }
 
+// GetDiff returns the changes Commit would record: the staged changes, or
+// those of the paths set with SetCommitPaths.
 func (g *Git) GetDiff() (string, error) {
-	diff, err := g.runGit("diff", "--staged")
+	diff, err := g.runGit(g.changesArgs()...)
 	if err != nil {
 		return "", fmt.Errorf("failed to get git diff: %w", err)
 	}
 	if strings.TrimSpace(diff) == "" {
-		return "", fmt.Errorf("no staged changes found")
+		return "", ErrNoStagedChanges
 	}
 
-	// 如果diff太长，截取一部分（限制在256KiB以内）
-	const maxDiffBytes = 256 * 1024
+	return truncateDiff(diff), nil
+}
+
+// truncateDiff 如果diff太长，截取一部分（限制在256KiB以内）
+func truncateDiff(diff string) string {
 	if len(diff) > maxDiffBytes {
 		diff = diff[:maxDiffBytes] + "\n... (diff truncated due to length)"
 	}
-
-	return diff, nil
+	return diff
 }
 
-func (g *Git) Commit(message string) error {
+func (g *Git) Commit(message string) (err error) {
+	restore, err := g.applyPendingStage()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, restore())
+		}
+	}()
+
 	tmpFile, err := os.CreateTemp("", "aicommit-commit-*.txt")
 	if err != nil {
 		return fmt.Errorf("failed to create temp commit message file: %w", err)
@@ -103,15 +143,25 @@ func (g *Git) Commit(message string) error {
 		return fmt.Errorf("failed to close temp commit message file: %w", err)
 	}
 
+	args, err := g.signingArgs("commit", "-F", tmpFile.Name())
+	if err != nil {
+		return err
+	}
+	if g.signing.Enabled {
+		args = append(args, "-S"+strings.TrimSpace(g.signing.Key))
+	}
+	args = withPathspec(args, g.commitPaths)
+
 	// #nosec G204 -- We execute the git binary with explicit arguments (no shell).
-	cmd := exec.Command("git", "commit", "-F", tmpFile.Name())
+	cmd := exec.Command("git", args...)
 	cmd.Dir = g.workDir
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	var stderr bytes.Buffer
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to commit: %w", err)
+		return g.wrapSigningError("commit", err, stderr.String())
 	}
 
 	return nil
@@ -128,8 +178,58 @@ func (g *Git) IsRepository() bool {
 }
 
 func (g *Git) LatestTag() (tag string, ok bool, err error) {
+	return g.PreviousTag(TagQuery{})
+}
+
+// TagQuery selects the tag that PreviousTag returns.
+type TagQuery struct {
+	// Rev is the revision to describe; HEAD when empty.
+	Rev string
+	// Pattern is a glob such as "api/v*" that tag names must match.
+	Pattern string
+	// SkipPrerelease ignores semver prerelease tags such as v1.2.0-rc.1.
+	SkipPrerelease bool
+}
+
+// maxSkippedTags bounds how many prerelease tags PreviousTag skips.
+const maxSkippedTags = 100
+
+// PreviousTag returns the nearest tag reachable from q.Rev that satisfies q.
+func (g *Git) PreviousTag(q TagQuery) (tag string, ok bool, err error) {
+	rev := strings.TrimSpace(q.Rev)
+	if rev == "" {
+		rev = "HEAD"
+	}
+	if strings.HasPrefix(rev, "-") {
+		return "", false, fmt.Errorf("invalid revision: %q", rev)
+	}
+
+	var excluded []string
+	for i := 0; i <= maxSkippedTags; i++ {
+		tag, ok, err = g.describeTag(rev, strings.TrimSpace(q.Pattern), excluded)
+		if err != nil || !ok {
+			return tag, ok, err
+		}
+		if !q.SkipPrerelease || !isPrereleaseTag(tag) {
+			return tag, true, nil
+		}
+		excluded = append(excluded, tag)
+	}
+	return "", false, fmt.Errorf("failed to get latest tag: more than %d prerelease tags to skip", maxSkippedTags)
+}
+
+func (g *Git) describeTag(rev, pattern string, excluded []string) (tag string, ok bool, err error) {
+	args := []string{"describe", "--tags", "--abbrev=0"}
+	if pattern != "" {
+		args = append(args, "--match", pattern)
+	}
+	for _, e := range excluded {
+		args = append(args, "--exclude", e)
+	}
+	args = append(args, rev)
+
 	// #nosec G204 -- We execute the git binary with explicit arguments (no shell).
-	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
+	cmd := exec.Command("git", args...)
 	cmd.Dir = g.workDir
 
 	var stdout bytes.Buffer
@@ -155,6 +255,14 @@ func (g *Git) LatestTag() (tag string, ok bool, err error) {
 	return tag, true, nil
 }
 
+// prereleaseTagPattern matches the version part of tags like "v1.2.0-rc.1"
+// or "libs/auth/1.0.0-beta".
+var prereleaseTagPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+-`)
+
+func isPrereleaseTag(tag string) bool {
+	return prereleaseTagPattern.MatchString(tag[strings.LastIndex(tag, "/")+1:])
+}
+
 func (g *Git) TagExists(tag string) (bool, error) {
 	if err := validateTagName(tag); err != nil {
 		return false, err
@@ -203,26 +311,120 @@ func (g *Git) CommitSubjects(rangeSpec string, max int) ([]string, bool, error)
 	return lines, truncated, nil
 }
 
-func (g *Git) DiffStat(rangeSpec string) (string, error) {
+// DiffStat returns the diffstat of rangeSpec, limited to paths when given.
+func (g *Git) DiffStat(rangeSpec string, paths ...string) (string, error) {
 	rangeSpec = strings.TrimSpace(rangeSpec)
 	if rangeSpec == "" {
 		return "", fmt.Errorf("rangeSpec cannot be empty")
 	}
-	return g.runGit("diff", "--stat", rangeSpec)
+	return g.runGit(withPathspec([]string{"diff", "--stat", rangeSpec}, paths)...)
 }
 
-func (g *Git) DiffNameStatus(rangeSpec string) (string, error) {
+// DiffNameStatus returns the changed files of rangeSpec, limited to paths
+// when given.
+func (g *Git) DiffNameStatus(rangeSpec string, paths ...string) (string, error) {
 	rangeSpec = strings.TrimSpace(rangeSpec)
 	if rangeSpec == "" {
 		return "", fmt.Errorf("rangeSpec cannot be empty")
 	}
-	return g.runGit("diff", "--name-status", rangeSpec)
+	return g.runGit(withPathspec([]string{"diff", "--name-status", rangeSpec}, paths)...)
+}
+
+// StagedFiles returns the paths of the staged changes, or of the changes in
+// the commit paths, relative to the repository root.
+func (g *Git) StagedFiles() ([]string, error) {
+	out, err := g.runGit(g.changesArgs("--name-only", "-z")...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staged files: %w", err)
+	}
+
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// TopLevel returns the absolute path of the working tree root.
+func (g *Git) TopLevel() (string, error) {
+	out, err := g.runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository root: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// StagedNameStatus returns the name-status listing of the staged changes, or
+// of the changes in the commit paths.
+func (g *Git) StagedNameStatus() (string, error) {
+	out, err := g.runGit(g.changesArgs("--name-status")...)
+	if err != nil {
+		return "", fmt.Errorf("failed to list staged changes: %w", err)
+	}
+	return out, nil
+}
+
+// CommentChar returns git's core.commentChar setting: "#" when unset, or
+// "auto" when git should pick a character not used by the message.
+func (g *Git) CommentChar() string {
+	if c := g.configValue("core.commentChar"); c != "" {
+		return c
+	}
+	return "#"
+}
+
+// CoreEditor returns git's core.editor setting, or "" when unset.
+func (g *Git) CoreEditor() string {
+	return g.configValue("core.editor")
+}
+
+// CommitFormat returns the repository's aicommit.format setting, or "" when
+// unset.
+func (g *Git) CommitFormat() string {
+	return g.configValue("aicommit.format")
+}
+
+// Language returns the repository's aicommit.language setting, or "" when
+// unset.
+func (g *Git) Language() string {
+	return g.configValue("aicommit.language")
+}
+
+// Verbose reports whether commit.verbose asks for the diff in the editor.
+func (g *Git) Verbose() bool {
+	switch strings.ToLower(g.configValue("commit.verbose")) {
+	case "", "false", "no", "off", "0":
+		return false
+	}
+	return true
+}
+
+// withPathspec appends paths after "--" so they are never taken for revisions.
+func withPathspec(args, paths []string) []string {
+	if len(paths) == 0 {
+		return args
+	}
+	args = append(args, "--")
+	return append(args, paths...)
 }
 
 func (g *Git) CreateAnnotatedTag(tag string, message string) error {
+	return g.CreateAnnotatedTagAt(tag, message, "")
+}
+
+// CreateAnnotatedTagAt creates an annotated tag pointing at target (HEAD when empty).
+func (g *Git) CreateAnnotatedTagAt(tag, message, target string) error {
 	if err := validateTagName(tag); err != nil {
 		return err
 	}
+	target = strings.TrimSpace(target)
+	if target != "" {
+		if _, err := g.ResolveRevision(target); err != nil {
+			return err
+		}
+	}
 
 	tmpFile, err := os.CreateTemp("", "aicommit-tag-*.txt")
 	if err != nil {
@@ -243,16 +445,255 @@ func (g *Git) CreateAnnotatedTag(tag string, message string) error {
 		return fmt.Errorf("failed to close temp tag message file: %w", err)
 	}
 
+	tagArgs := []string{"tag", "-a"}
+	if g.signing.Enabled {
+		if key := strings.TrimSpace(g.signing.Key); key != "" {
+			tagArgs = []string{"tag", "-u", key}
+		} else {
+			tagArgs = []string{"tag", "-s"}
+		}
+	}
+	tagArgs = append(tagArgs, "-F", tmpFile.Name(), strings.TrimSpace(tag))
+	if target != "" {
+		tagArgs = append(tagArgs, target)
+	}
+	args, err := g.signingArgs(tagArgs...)
+	if err != nil {
+		return err
+	}
+
 	// #nosec G204 -- We execute the git binary with explicit arguments (no shell); tag is validated.
-	cmd := exec.Command("git", "tag", "-a", strings.TrimSpace(tag), "-F", tmpFile.Name())
+	cmd := exec.Command("git", args...)
 	cmd.Dir = g.workDir
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	var stderr bytes.Buffer
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to create annotated tag: %w", err)
+		return g.wrapSigningError("create annotated tag", err, stderr.String())
+	}
+
+	return nil
+}
+
+// CommitMessages returns the full messages (subject and body) of the commits
+// in rangeSpec, newest first. When paths are given only commits touching them
+// are included.
+func (g *Git) CommitMessages(rangeSpec string, max int, paths ...string) ([]string, bool, error) {
+	args := []string{"log", "-z", "--pretty=format:%B"}
+	rangeSpec = strings.TrimSpace(rangeSpec)
+	if rangeSpec != "" {
+		args = append(args, rangeSpec)
+	}
+	args = withPathspec(args, paths)
+
+	out, err := g.runGit(args...)
+	if err != nil {
+		return nil, false, err
 	}
 
+	var messages []string
+	for _, m := range strings.Split(out, "\x00") {
+		m = strings.TrimSpace(m)
+		if m != "" {
+			messages = append(messages, m)
+		}
+	}
+
+	truncated := false
+	if max > 0 && len(messages) > max {
+		messages = messages[:max]
+		truncated = true
+	}
+
+	return messages, truncated, nil
+}
+
+// RecentCommitMessages returns the full messages of the n most recent
+// non-merge commits reachable from HEAD, limited to commits touching paths
+// when given.
+func (g *Git) RecentCommitMessages(n int, paths ...string) ([]string, error) {
+	args := withPathspec([]string{"log", "-z", "--no-merges", "-n", strconv.Itoa(n), "--pretty=format:%B"}, paths)
+	out, err := g.runGit(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, m := range strings.Split(out, "\x00") {
+		if m = strings.TrimSpace(m); m != "" {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// DiffRange returns the combined diff for rangeSpec, truncated like GetDiff.
+func (g *Git) DiffRange(rangeSpec string) (string, error) {
+	rangeSpec = strings.TrimSpace(rangeSpec)
+	if rangeSpec == "" {
+		return "", fmt.Errorf("rangeSpec cannot be empty")
+	}
+
+	diff, err := g.runGit("diff", rangeSpec)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return "", fmt.Errorf("no changes found in %s", rangeSpec)
+	}
+
+	return truncateDiff(diff), nil
+}
+
+// Show returns the message, diffstat and patch of commit rev, truncated like
+// GetDiff.
+func (g *Git) Show(rev string) (string, error) {
+	if _, err := g.ResolveRevision(rev); err != nil {
+		return "", err
+	}
+
+	out, err := g.runGit("show", "--format=fuller", "--stat", "--patch", "--end-of-options", strings.TrimSpace(rev))
+	if err != nil {
+		return "", fmt.Errorf("failed to show %s: %w", rev, err)
+	}
+	return truncateDiff(out), nil
+}
+
+// ResolveRevision returns the full object name of rev.
+func (g *Git) ResolveRevision(rev string) (string, error) {
+	rev = strings.TrimSpace(rev)
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", fmt.Errorf("invalid revision: %q", rev)
+	}
+
+	out, err := g.runGit("rev-parse", "--verify", "--end-of-options", rev+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown revision %s: %w", rev, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// MergeBase returns the best common ancestor of a and b.
+func (g *Git) MergeBase(a, b string) (string, error) {
+	if _, err := g.ResolveRevision(a); err != nil {
+		return "", err
+	}
+	if _, err := g.ResolveRevision(b); err != nil {
+		return "", err
+	}
+
+	out, err := g.runGit("merge-base", a, b)
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", a, b, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// HasStagedChanges reports whether the index differs from HEAD.
+func (g *Git) HasStagedChanges() (bool, error) {
+	out, err := g.runGit("diff", "--staged", "--name-only")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// SoftReset moves HEAD to rev while keeping the index and working tree.
+func (g *Git) SoftReset(rev string) error {
+	if _, err := g.ResolveRevision(rev); err != nil {
+		return err
+	}
+	if _, err := g.runGit("reset", "--soft", rev); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", rev, err)
+	}
+	return nil
+}
+
+// MergeSquash stages the changes of rev on top of HEAD without committing.
+func (g *Git) MergeSquash(rev string) error {
+	if _, err := g.ResolveRevision(rev); err != nil {
+		return err
+	}
+	if _, err := g.runGit("merge", "--squash", rev); err != nil {
+		return fmt.Errorf("failed to squash-merge %s: %w", rev, err)
+	}
+	return nil
+}
+
+// CurrentBranch returns the short name of the checked-out branch, or "HEAD"
+// when HEAD is detached.
+func (g *Git) CurrentBranch() (string, error) {
+	out, err := g.runGit("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// DefaultBranch guesses the branch pull requests target: the remote HEAD of
+// origin when known, otherwise a local main or master branch.
+func (g *Git) DefaultBranch() (string, error) {
+	if out, err := g.runGit("symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		if branch := strings.TrimSpace(out); branch != "" {
+			return branch, nil
+		}
+	}
+
+	for _, candidate := range []string{"main", "master"} {
+		if _, err := g.ResolveRevision(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("cannot determine default branch; pass the base branch explicitly")
+}
+
+// Add stages the given paths.
+func (g *Git) Add(paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths to add")
+	}
+	args := append([]string{"add", "--"}, paths...)
+	if _, err := g.runGit(args...); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", strings.Join(paths, ", "), err)
+	}
+	return nil
+}
+
+// RemoteURL returns the fetch URL of remote.
+func (g *Git) RemoteURL(remote string) (string, error) {
+	remote = strings.TrimSpace(remote)
+	if remote == "" || strings.HasPrefix(remote, "-") {
+		return "", fmt.Errorf("invalid remote name: %q", remote)
+	}
+	out, err := g.runGit("remote", "get-url", remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %s: %w", remote, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// PushTag pushes a single tag to remote.
+func (g *Git) PushTag(remote, tag string) error {
+	if err := validateTagName(tag); err != nil {
+		return err
+	}
+	remote = strings.TrimSpace(remote)
+	if remote == "" || strings.HasPrefix(remote, "-") {
+		return fmt.Errorf("invalid remote name: %q", remote)
+	}
+
+	// #nosec G204 -- We execute the git binary with explicit arguments (no shell); tag is validated.
+	cmd := exec.Command("git", "push", remote, "refs/tags/"+strings.TrimSpace(tag))
+	cmd.Dir = g.workDir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to push tag %s to %s: %w", tag, remote, err)
+	}
 	return nil
 }
@@ -1,9 +1,15 @@
 package git
 
 import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGit_IsRepository(t *testing.T) {
@@ -31,7 +37,7 @@ func TestGit_GetDiff(t *testing.T) {
 	diff, err := git.GetDiff()
 
 	// It's OK if there are no staged changes in CI environment
-	if err != nil && err.Error() == "no staged changes found" {
+	if errors.Is(err, ErrNoStagedChanges) {
 		t.Log("No staged changes found - this is expected in CI")
 		return
 	}
@@ -42,3 +48,74 @@ func TestGit_GetDiff(t *testing.T) {
 		assert.NotEmpty(t, diff, "Diff should not be empty when there are staged changes")
 	}
 }
+
+func TestGit_BranchHelpers(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-b", "master")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+
+	g := New(dir)
+	_, err := g.DefaultBranch()
+	assert.Error(t, err, "unborn branch should not be reported as default")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+	runGit(t, dir, "commit", "-m", "feat: init")
+	runGit(t, dir, "checkout", "-b", "feature/x")
+
+	branch, err := g.CurrentBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "feature/x", branch)
+
+	def, err := g.DefaultBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "master", def)
+
+	runGit(t, dir, "branch", "main")
+	def, err = g.DefaultBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", def)
+
+	runGit(t, dir, "update-ref", "refs/remotes/origin/develop", "HEAD")
+	runGit(t, dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/develop")
+	def, err = g.DefaultBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "origin/develop", def)
+}
+
+func TestGit_EditorSettings(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+
+	g := New(dir)
+	assert.Equal(t, "#", g.CommentChar())
+	assert.False(t, g.Verbose())
+	assert.Empty(t, g.Language())
+	assert.Empty(t, g.CommitFormat())
+
+	runGit(t, dir, "config", "core.commentChar", ";")
+	runGit(t, dir, "config", "commit.verbose", "true")
+	runGit(t, dir, "config", "aicommit.language", "de")
+	runGit(t, dir, "config", "aicommit.format", "gitmoji")
+	assert.Equal(t, ";", g.CommentChar())
+	assert.True(t, g.Verbose())
+	assert.Equal(t, "de", g.Language())
+	assert.Equal(t, "gitmoji", g.CommitFormat())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+	status, err := g.StagedNameStatus()
+	require.NoError(t, err)
+	assert.Equal(t, "A\ta.txt", strings.TrimSpace(status))
+}
@@ -0,0 +1,137 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	logFieldSep  = "\x1f"
+	logRecordSep = "\x1e"
+)
+
+// commitLogFormat lists the fields parsed by parseCommitLog, in order.
+var commitLogFormat = strings.Join([]string{
+	"%H", "%h", "%an", "%ae", "%aI", "%s", "%b", "%(trailers:only,unfold)",
+}, "%x1f") + "%x1e"
+
+// pullRequestPattern matches "(#123)" as appended by GitHub squash merges and
+// "Merge pull request #123" merge commit subjects.
+var pullRequestPattern = regexp.MustCompile(`\(#(\d+)\)|^Merge pull request #(\d+)`)
+
+// Trailer is a "Key: value" line from the trailer block of a commit message.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// CommitInfo is one entry of a structured commit log.
+type CommitInfo struct {
+	Hash        string
+	ShortHash   string
+	Author      string
+	AuthorEmail string
+	Date        time.Time
+	Subject     string
+	// Body is the message after the subject, including any trailers.
+	Body     string
+	Trailers []Trailer
+	// PullRequests are the pull/merge request numbers referenced by the subject.
+	PullRequests []int
+}
+
+// CommitLog returns the commits in rangeSpec (all of HEAD when empty), newest
+// first. At most max commits are returned when max > 0. When paths are given
+// only commits touching them are included.
+func (g *Git) CommitLog(rangeSpec string, max int, paths ...string) ([]CommitInfo, bool, error) {
+	args := []string{"log", "--pretty=format:" + commitLogFormat}
+	if max > 0 {
+		// One more than max tells whether the log was truncated.
+		args = append(args, "--max-count="+strconv.Itoa(max+1))
+	}
+	rangeSpec = strings.TrimSpace(rangeSpec)
+	if rangeSpec != "" {
+		args = append(args, rangeSpec)
+	}
+	args = withPathspec(args, paths)
+
+	out, err := g.runGit(args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	commits, err := parseCommitLog(out)
+	if err != nil {
+		return nil, false, err
+	}
+
+	truncated := false
+	if max > 0 && len(commits) > max {
+		commits = commits[:max]
+		truncated = true
+	}
+	return commits, truncated, nil
+}
+
+func parseCommitLog(out string) ([]CommitInfo, error) {
+	var commits []CommitInfo
+	for _, record := range strings.Split(out, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		fields := strings.Split(record, logFieldSep)
+		if len(fields) != 8 {
+			return nil, fmt.Errorf("unexpected git log output: %d fields", len(fields))
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid commit date %q: %w", fields[4], err)
+		}
+
+		c := CommitInfo{
+			Hash:         fields[0],
+			ShortHash:    fields[1],
+			Author:       fields[2],
+			AuthorEmail:  fields[3],
+			Date:         date,
+			Subject:      fields[5],
+			Body:         strings.TrimSpace(fields[6]),
+			Trailers:     parseTrailers(fields[7]),
+			PullRequests: parsePullRequests(fields[5]),
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+func parseTrailers(s string) []Trailer {
+	var trailers []Trailer
+	for _, line := range strings.Split(s, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		trailers = append(trailers, Trailer{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+	return trailers
+}
+
+func parsePullRequests(subject string) []int {
+	var prs []int
+	for _, m := range pullRequestPattern.FindAllStringSubmatch(subject, -1) {
+		digits := m[1]
+		if digits == "" {
+			digits = m[2]
+		}
+		if n, err := strconv.Atoi(digits); err == nil {
+			prs = append(prs, n)
+		}
+	}
+	return prs
+}
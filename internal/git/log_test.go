@@ -0,0 +1,136 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_CommitLog(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+	runGit(t, dir, "commit", "-m", "feat: init")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello world\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+	runGit(t, dir,
+		"-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com",
+		"commit",
+		"-m", "fix(api): handle timeouts (#42)",
+		"-m", "Requests no longer hang forever.",
+		"-m", "Refs: PROJ-7\nCo-authored-by: Bob <bob@example.com>",
+	)
+
+	g := New(dir)
+	commits, truncated, err := g.CommitLog("", 0)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	require.Len(t, commits, 2)
+
+	c := commits[0]
+	assert.Len(t, c.Hash, 40)
+	assert.NotEmpty(t, c.ShortHash)
+	assert.Equal(t, "Jane Doe", c.Author)
+	assert.Equal(t, "jane@example.com", c.AuthorEmail)
+	assert.False(t, c.Date.IsZero())
+	assert.Equal(t, "fix(api): handle timeouts (#42)", c.Subject)
+	assert.Contains(t, c.Body, "Requests no longer hang forever.")
+	assert.Equal(t, []Trailer{
+		{Key: "Refs", Value: "PROJ-7"},
+		{Key: "Co-authored-by", Value: "Bob <bob@example.com>"},
+	}, c.Trailers)
+	assert.Equal(t, []int{42}, c.PullRequests)
+
+	assert.Equal(t, "feat: init", commits[1].Subject)
+	assert.Empty(t, commits[1].Body)
+	assert.Empty(t, commits[1].Trailers)
+
+	commits, truncated, err = g.CommitLog("HEAD~1..HEAD", 0)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Len(t, commits, 1)
+
+	commits, truncated, err = g.CommitLog("", 1)
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, commits, 1)
+
+	commits, truncated, err = g.CommitLog("", 2)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Len(t, commits, 2)
+}
+
+func TestParsePullRequests(t *testing.T) {
+	assert.Equal(t, []int{12}, parsePullRequests("Merge pull request #12 from org/branch"))
+	assert.Equal(t, []int{3, 4}, parsePullRequests("feat: a (#3) (#4)"))
+	assert.Empty(t, parsePullRequests("fix: issue #5 without parentheses"))
+}
+
+func TestGit_PathLimitedHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "libs", "auth"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "services", "api"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("hi\n"), 0o644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "chore: init")
+	runGit(t, dir, "tag", "base")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "libs", "auth", "auth.go"), []byte("package auth\n"), 0o644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "feat(auth): add auth")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "services", "api", "main.go"), []byte("package main\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\n"), 0o644))
+	runGit(t, dir, "add", ".")
+
+	g := New(dir)
+	staged, err := g.StagedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "services/api/main.go"}, staged)
+
+	runGit(t, dir, "commit", "-m", "feat(api): add api")
+
+	commits, _, err := g.CommitLog("base..HEAD", 0, "libs/auth")
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "feat(auth): add auth", commits[0].Subject)
+
+	messages, _, err := g.CommitMessages("base..HEAD", 0, "services/api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat(api): add api"}, messages)
+
+	recent, err := g.RecentCommitMessages(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat(api): add api", "feat(auth): add auth", "chore: init"}, recent)
+
+	recent, err = g.RecentCommitMessages(1, "libs/auth", "services/api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"feat(api): add api"}, recent)
+
+	stat, err := g.DiffNameStatus("base..HEAD", "libs/auth")
+	require.NoError(t, err)
+	assert.Contains(t, stat, "libs/auth/auth.go")
+	assert.NotContains(t, stat, "services/api")
+}
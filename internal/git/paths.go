@@ -0,0 +1,185 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SetCommitPaths restricts the next commit to paths, like git commit
+// <pathspec>: GetDiff, StagedFiles and StagedNameStatus describe the working
+// tree content of the paths compared to HEAD, and Commit records only them.
+// Other staged changes stay in the index.
+func (g *Git) SetCommitPaths(paths []string) {
+	g.commitPaths = paths
+}
+
+// changesArgs returns the diff arguments that select the changes Commit
+// records: the index, or the commit paths in the working tree.
+func (g *Git) changesArgs(args ...string) []string {
+	if len(g.commitPaths) == 0 {
+		return append([]string{"diff", "--staged"}, args...)
+	}
+	return withPathspec(append([]string{"diff", "HEAD"}, args...), g.commitPaths)
+}
+
+// CheckPaths fails like git commit does when a pathspec matches no file
+// known to git.
+func (g *Git) CheckPaths(paths ...string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	if _, err := g.runGit(withPathspec([]string{"ls-files", "--error-unmatch"}, paths)...); err != nil {
+		return fmt.Errorf("failed to check paths: %w", err)
+	}
+	return nil
+}
+
+// pendingStage is the staging that StageOnCommit defers to Commit.
+type pendingStage struct {
+	tracked   bool
+	untracked []string
+	// index is the path of the real index.
+	index string
+}
+
+func (g *Git) stage(p pendingStage) error {
+	if len(p.untracked) > 0 {
+		if err := g.Add(p.untracked...); err != nil {
+			return err
+		}
+	}
+	if p.tracked {
+		return g.StageTracked()
+	}
+	return nil
+}
+
+// StageOnCommit stages the changes of all tracked files (with tracked, like
+// git add -u) and the untracked files like git commit -a: GetDiff and the
+// other queries see them in a temporary copy of the index, and Commit
+// stages them in the real index right before committing, restoring it if
+// the commit fails. Until then the index is left untouched; Close discards
+// the copy.
+func (g *Git) StageOnCommit(tracked bool, untracked []string) error {
+	p := pendingStage{tracked: tracked, untracked: untracked}
+	if !p.tracked && len(p.untracked) == 0 {
+		return nil
+	}
+
+	out, err := g.runGit("rev-parse", "--git-path", "index")
+	if err != nil {
+		return fmt.Errorf("failed to locate index: %w", err)
+	}
+	p.index = strings.TrimSpace(out)
+	if !filepath.IsAbs(p.index) {
+		p.index = filepath.Join(g.workDir, p.index)
+	}
+	data, err := readIndex(p.index)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "aicommit-index-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index: %w", err)
+	}
+	g.indexFile = filepath.Join(dir, "index")
+	if data != nil {
+		if err := os.WriteFile(g.indexFile, data, 0o600); err != nil {
+			_ = g.Close()
+			return fmt.Errorf("failed to create temporary index: %w", err)
+		}
+	}
+	if err := g.stage(p); err != nil {
+		_ = g.Close()
+		return err
+	}
+	g.pending = p
+	return nil
+}
+
+// applyPendingStage stages the changes of StageOnCommit in the real index.
+// The returned restore func puts the index back as it was, for when the
+// commit fails.
+func (g *Git) applyPendingStage() (restore func() error, err error) {
+	restore = func() error { return nil }
+	if g.indexFile == "" {
+		return restore, nil
+	}
+	p := g.pending
+	if err := g.Close(); err != nil {
+		return restore, err
+	}
+
+	saved, err := readIndex(p.index)
+	if err != nil {
+		return restore, err
+	}
+	restore = func() error {
+		var err error
+		if saved == nil {
+			err = os.Remove(p.index)
+		} else {
+			err = os.WriteFile(p.index, saved, 0o600)
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to restore index: %w", err)
+		}
+		return nil
+	}
+	if err := g.stage(p); err != nil {
+		return restore, errors.Join(err, restore())
+	}
+	return restore, nil
+}
+
+// readIndex returns the content of the index at path, or nil when there is
+// none yet.
+func readIndex(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- Path comes from git.
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	return data, nil
+}
+
+// Close discards the temporary index of StageOnCommit, if any.
+func (g *Git) Close() error {
+	if g.indexFile == "" {
+		return nil
+	}
+	dir := filepath.Dir(g.indexFile)
+	g.indexFile, g.pending = "", pendingStage{}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove temporary index: %w", err)
+	}
+	return nil
+}
+
+// StageTracked stages the changes of all tracked files, like git add -u.
+func (g *Git) StageTracked() error {
+	if _, err := g.runGit("add", "-u"); err != nil {
+		return fmt.Errorf("failed to stage tracked changes: %w", err)
+	}
+	return nil
+}
+
+// UntrackedFiles lists the untracked files that are not ignored, optionally
+// limited to paths.
+func (g *Git) UntrackedFiles(paths ...string) ([]string, error) {
+	out, err := g.runGit(withPathspec([]string{"ls-files", "--others", "--exclude-standard", "-z"}, paths)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
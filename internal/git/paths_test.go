@@ -0,0 +1,112 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_CommitPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	write("api/a.txt", "a\n")
+	write("web/b.txt", "b\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "feat: init")
+
+	// An unstaged change in api/ and a staged one in web/.
+	write("api/a.txt", "a2\n")
+	write("web/b.txt", "b2\n")
+	runGit(t, dir, "add", "web/b.txt")
+
+	g := New(dir)
+	require.Error(t, g.CheckPaths("missing"))
+	require.NoError(t, g.CheckPaths("api"))
+
+	g.SetCommitPaths([]string{"api"})
+	diff, err := g.GetDiff()
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+a2")
+	assert.NotContains(t, diff, "b2")
+	files, err := g.StagedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/a.txt"}, files)
+
+	require.NoError(t, g.Commit("fix(api): update a"))
+	assert.Equal(t, "api/a.txt", strings.TrimSpace(runGit(t, dir, "show", "--name-only", "--format=", "HEAD")))
+	assert.Equal(t, "web/b.txt", strings.TrimSpace(runGit(t, dir, "diff", "--staged", "--name-only")), "other staged changes stay in the index")
+
+	g.SetCommitPaths(nil)
+	write("new.txt", "new\n")
+	write("api/a.txt", "a3\n")
+	untracked, err := g.UntrackedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new.txt"}, untracked)
+	require.NoError(t, g.StageTracked())
+	files, err = g.StagedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api/a.txt", "web/b.txt"}, files)
+
+	g.SetCommitPaths([]string{"api"})
+	runGit(t, dir, "commit", "-m", "chore: commit everything", "-a")
+	_, err = g.GetDiff()
+	assert.True(t, errors.Is(err, ErrNoStagedChanges))
+}
+
+func TestGit_StageOnCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0o644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "feat: init")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a2\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new\n"), 0o644))
+
+	g := New(dir)
+	require.NoError(t, g.StageOnCommit(true, []string{"new.txt"}))
+	diff, err := g.GetDiff()
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+a2")
+	assert.Contains(t, diff, "+new")
+	assert.Empty(t, runGit(t, dir, "diff", "--staged", "--name-only"), "the index is left untouched until Commit")
+
+	require.NoError(t, g.Close())
+	_, err = g.GetDiff()
+	assert.True(t, errors.Is(err, ErrNoStagedChanges))
+
+	hook := filepath.Join(dir, ".git", "hooks", "pre-commit")
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0o755))
+	require.NoError(t, g.StageOnCommit(true, []string{"new.txt"}))
+	assert.Error(t, g.Commit("feat: update a"))
+	assert.Empty(t, runGit(t, dir, "diff", "--staged", "--name-only"), "a failed commit restores the index")
+	require.NoError(t, os.Remove(hook))
+
+	require.NoError(t, g.StageOnCommit(true, []string{"new.txt"}))
+	require.NoError(t, g.Commit("feat: update a"))
+	assert.Equal(t, "a.txt\nnew.txt", strings.TrimSpace(runGit(t, dir, "show", "--name-only", "--format=", "HEAD")))
+	assert.Empty(t, strings.TrimSpace(runGit(t, dir, "status", "--porcelain")))
+}
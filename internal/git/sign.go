@@ -0,0 +1,109 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSigningFailed is wrapped by Commit and CreateAnnotatedTag when git could
+// not sign the object (missing key, gpg-agent or ssh-keygen failure, ...).
+var ErrSigningFailed = errors.New("signing failed")
+
+// SignOptions configures GPG, SSH or X.509 signing of commits and tags.
+type SignOptions struct {
+	Enabled bool
+	// Key overrides git's user.signingkey when set.
+	Key string
+	// Format overrides git's gpg.format (openpgp, ssh or x509) when set.
+	Format string
+}
+
+// SetSigning makes Commit and CreateAnnotatedTag sign the objects they create.
+func (g *Git) SetSigning(opts SignOptions) {
+	g.signing = opts
+}
+
+// signingArgs prepends the gpg.format override to args and checks that the
+// effective configuration can sign at all.
+func (g *Git) signingArgs(args ...string) ([]string, error) {
+	if !g.signing.Enabled {
+		return args, nil
+	}
+
+	format := strings.ToLower(strings.TrimSpace(g.signing.Format))
+	switch format {
+	case "":
+		format = g.configValue("gpg.format")
+	case "openpgp", "ssh", "x509":
+		args = append([]string{"-c", "gpg.format=" + format}, args...)
+	default:
+		return nil, fmt.Errorf("unsupported signing format %q (expected openpgp, ssh or x509)", g.signing.Format)
+	}
+
+	if format == "ssh" && strings.TrimSpace(g.signing.Key) == "" && g.configValue("user.signingkey") == "" {
+		return nil, fmt.Errorf("%w: SSH signing requires a key; set git's user.signingkey or signing.key in the aicommit config", ErrSigningFailed)
+	}
+
+	return args, nil
+}
+
+// wrapSigningError turns a failed commit/tag into an ErrSigningFailed error
+// when git's output shows that signing was the problem.
+func (g *Git) wrapSigningError(action string, err error, stderr string) error {
+	if !g.signing.Enabled || !isSigningFailure(stderr) {
+		return fmt.Errorf("failed to %s: %w", action, err)
+	}
+
+	format := strings.TrimSpace(g.signing.Format)
+	if format == "" {
+		format = g.configValue("gpg.format")
+	}
+	if format == "" {
+		format = "openpgp"
+	}
+	key := strings.TrimSpace(g.signing.Key)
+	if key == "" {
+		key = g.configValue("user.signingkey")
+	}
+	if key == "" {
+		key = "(default)"
+	}
+
+	return fmt.Errorf("failed to %s: %w (gpg.format=%s, key=%s): %w: %s", action, ErrSigningFailed, format, key, err, firstLine(stderr))
+}
+
+func isSigningFailure(stderr string) bool {
+	s := strings.ToLower(stderr)
+	for _, marker := range []string{
+		"failed to sign",
+		"unable to sign",
+		"gpg failed",
+		"ssh-keygen",
+		"signing key",
+		"load key",
+		"couldn't load public key",
+	} {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Git) configValue(key string) string {
+	out, err := g.runGit("config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+func firstLine(s string) string {
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
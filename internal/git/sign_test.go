@@ -0,0 +1,83 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_SSHSigning(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(t.TempDir(), "signing_key")
+	// #nosec G204 -- test helper with fixed arguments.
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "test@example.com", "-f", keyFile).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+	runGit(t, dir, "config", "commit.gpgsign", "false")
+	runGit(t, dir, "config", "tag.gpgsign", "false")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+
+	g := New(dir)
+	g.SetSigning(SignOptions{Enabled: true, Format: "ssh"})
+	err = g.Commit("feat: signed")
+	require.Error(t, err, "SSH signing without a key must fail before running git")
+	assert.True(t, errors.Is(err, ErrSigningFailed))
+
+	g.SetSigning(SignOptions{Enabled: true, Format: "ssh", Key: keyFile})
+	require.NoError(t, g.Commit("feat: signed"))
+	assert.Contains(t, runGit(t, dir, "cat-file", "commit", "HEAD"), "-----BEGIN SSH SIGNATURE-----")
+
+	require.NoError(t, g.CreateAnnotatedTag("v1.0.0", "Release v1.0.0"))
+	assert.Contains(t, runGit(t, dir, "cat-file", "tag", "v1.0.0"), "-----BEGIN SSH SIGNATURE-----")
+
+	// The key can also come from git's own configuration.
+	runGit(t, dir, "config", "gpg.format", "ssh")
+	runGit(t, dir, "config", "user.signingkey", keyFile)
+	g.SetSigning(SignOptions{Enabled: true})
+	require.NoError(t, g.CreateAnnotatedTag("v1.0.1", "Release v1.0.1"))
+	assert.Contains(t, runGit(t, dir, "cat-file", "tag", "v1.0.1"), "-----BEGIN SSH SIGNATURE-----")
+
+	// Unsigned objects stay unsigned.
+	g.SetSigning(SignOptions{})
+	require.NoError(t, g.CreateAnnotatedTag("v1.0.2", "Release v1.0.2"))
+	assert.NotContains(t, runGit(t, dir, "cat-file", "tag", "v1.0.2"), "SIGNATURE")
+	err = g.CreateAnnotatedTag("v1.0.2", "Release v1.0.2")
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "failed to create annotated tag: "), err.Error())
+
+	g.SetSigning(SignOptions{Enabled: true, Format: "ssh", Key: filepath.Join(dir, "missing_key")})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+	err = g.Commit("fix: not signed")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSigningFailed), err.Error())
+	assert.Contains(t, err.Error(), "gpg.format=ssh")
+
+	g.SetSigning(SignOptions{Enabled: true, Format: "pgp"})
+	assert.Error(t, g.Commit("fix: bad format"))
+}
+
+func TestIsSigningFailure(t *testing.T) {
+	assert.True(t, isSigningFailure("error: gpg failed to sign the data\nfatal: failed to write commit object"))
+	assert.True(t, isSigningFailure("error: Load key \"/tmp/missing\": No such file or directory\nfatal: failed to write commit object"))
+	assert.False(t, isSigningFailure("error: insufficient permission for adding an object to repository database .git/objects\nfatal: failed to write commit object"))
+	assert.False(t, isSigningFailure(""))
+}
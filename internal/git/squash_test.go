@@ -0,0 +1,90 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_SquashHelpers(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-b", "main")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello\n"), 0o644))
+	runGit(t, dir, "add", "a.txt")
+	runGit(t, dir, "commit", "-m", "feat: init")
+
+	runGit(t, dir, "checkout", "-b", "feature")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("one\n"), 0o644))
+	runGit(t, dir, "add", "b.txt")
+	runGit(t, dir, "commit", "-m", "wip", "-m", "first attempt")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("two\n"), 0o644))
+	runGit(t, dir, "commit", "-am", "fix review comments")
+
+	g := New(dir)
+
+	mainCommit := strings.TrimSpace(runGit(t, dir, "rev-parse", "main"))
+	mergeBase, err := g.MergeBase("main", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, mainCommit, mergeBase)
+
+	_, err = g.MergeBase("does-not-exist", "HEAD")
+	assert.Error(t, err)
+
+	rangeSpec := mergeBase + "..HEAD"
+	messages, truncated, err := g.CommitMessages(rangeSpec, 0)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, []string{"fix review comments", "wip\n\nfirst attempt"}, messages)
+
+	messages, truncated, err = g.CommitMessages(rangeSpec, 1)
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, messages, 1)
+
+	diff, err := g.DiffRange(rangeSpec)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "b.txt")
+	assert.Contains(t, diff, "+two")
+
+	show, err := g.Show("HEAD")
+	require.NoError(t, err)
+	assert.Contains(t, show, "fix review comments")
+	assert.Contains(t, show, "+two")
+	show, err = g.Show(mainCommit)
+	require.NoError(t, err, "the root commit has no parent to diff against")
+	assert.Contains(t, show, "+hello")
+	_, err = g.Show("does-not-exist")
+	assert.Error(t, err)
+
+	staged, err := g.HasStagedChanges()
+	require.NoError(t, err)
+	assert.False(t, staged)
+
+	require.NoError(t, g.SoftReset(mergeBase))
+	assert.Equal(t, mainCommit, strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD")))
+
+	staged, err = g.HasStagedChanges()
+	require.NoError(t, err)
+	assert.True(t, staged)
+
+	runGit(t, dir, "reset", "--hard", "feature@{1}")
+	runGit(t, dir, "checkout", "main")
+	require.NoError(t, g.MergeSquash("feature"))
+
+	staged, err = g.HasStagedChanges()
+	require.NoError(t, err)
+	assert.True(t, staged)
+	assert.Equal(t, mainCommit, strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD")))
+}
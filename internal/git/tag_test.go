@@ -71,6 +71,23 @@ func TestGit_TagHelpers(t *testing.T) {
 	contents := runGit(t, dir, "for-each-ref", "refs/tags/v0.2.0", "--format=%(contents)")
 	assert.Contains(t, contents, "Release v0.2.0")
 	assert.Contains(t, contents, "Added")
+
+	remoteDir := t.TempDir()
+	runGit(t, remoteDir, "init", "--bare")
+	runGit(t, dir, "remote", "add", "origin", remoteDir)
+
+	url, err := g.RemoteURL("origin")
+	require.NoError(t, err)
+	assert.Equal(t, remoteDir, url)
+
+	_, err = g.RemoteURL("missing")
+	assert.Error(t, err)
+
+	require.NoError(t, g.PushTag("origin", "v0.2.0"))
+	assert.Contains(t, runGit(t, remoteDir, "tag", "--list"), "v0.2.0")
+	assert.NotContains(t, runGit(t, remoteDir, "tag", "--list"), "v0.1.0")
+
+	assert.Error(t, g.PushTag("--all", "v0.2.0"))
 }
 
 func runGit(t *testing.T, dir string, args ...string) string {
@@ -91,3 +108,71 @@ func runGit(t *testing.T, dir string, args ...string) string {
 
 	return stdout.String()
 }
+
+func TestGit_PreviousTag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+
+	commit := func(msg string) {
+		runGit(t, dir, "commit", "--allow-empty", "-m", msg)
+	}
+
+	commit("feat: init")
+	runGit(t, dir, "tag", "-a", "v1.0.0", "-m", "Release v1.0.0")
+	runGit(t, dir, "tag", "-a", "api/v0.1.0", "-m", "Release api/v0.1.0")
+	commit("feat: second")
+	runGit(t, dir, "tag", "-a", "v1.1.0-rc.1", "-m", "Release v1.1.0-rc.1")
+	commit("fix: third")
+	runGit(t, dir, "tag", "-a", "web/v2.0.0", "-m", "Release web/v2.0.0")
+	commit("fix: fourth")
+
+	g := New(dir)
+
+	tag, ok, err := g.PreviousTag(TagQuery{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "web/v2.0.0", tag)
+
+	tag, ok, err = g.PreviousTag(TagQuery{Pattern: "v*"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.1.0-rc.1", tag)
+
+	tag, ok, err = g.PreviousTag(TagQuery{Pattern: "v*", SkipPrerelease: true})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0", tag)
+
+	tag, ok, err = g.PreviousTag(TagQuery{Pattern: "api/v*"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "api/v0.1.0", tag)
+
+	tag, ok, err = g.PreviousTag(TagQuery{Rev: "HEAD~2", Pattern: "v*"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.1.0-rc.1", tag)
+
+	_, ok, err = g.PreviousTag(TagQuery{Pattern: "cli/v*"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = g.PreviousTag(TagQuery{Rev: "--all"})
+	assert.Error(t, err)
+
+	require.NoError(t, g.CreateAnnotatedTagAt("v1.1.0", "Release v1.1.0", "HEAD~1"))
+	assert.Equal(t,
+		strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD~1")),
+		strings.TrimSpace(runGit(t, dir, "rev-parse", "v1.1.0^{commit}")),
+	)
+	assert.Error(t, g.CreateAnnotatedTagAt("v1.2.0", "Release v1.2.0", "no-such-rev"))
+
+	assert.True(t, isPrereleaseTag("libs/auth/v1.0.0-beta.2"))
+	assert.False(t, isPrereleaseTag("my-lib/v1.0.0"))
+}
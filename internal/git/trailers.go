@@ -0,0 +1,71 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddTrailers appends trailers to message with git interpret-trailers, so
+// they are formatted and placed the way git and other tooling expect.
+// Trailers already present with the same value are not added again.
+func (g *Git) AddTrailers(message string, trailers []Trailer) (string, error) {
+	if len(trailers) == 0 {
+		return message, nil
+	}
+
+	args := []string{"interpret-trailers", "--if-exists", "addIfDifferent"}
+	for _, t := range trailers {
+		args = append(args, "--trailer", fmt.Sprintf("%s: %s", t.Key, t.Value))
+	}
+
+	out, err := g.runGitInput(strings.TrimSpace(message)+"\n", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add trailers: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Identity returns git's committer identity as "Name <email>", the value
+// `git commit --signoff` uses.
+func (g *Git) Identity() (string, error) {
+	out, err := g.runGit("var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", fmt.Errorf("failed to get git identity: %w", err)
+	}
+
+	// "Name <email> 1700000000 +0100": drop the timestamp and time zone.
+	ident := strings.TrimSpace(out)
+	if i := strings.LastIndex(ident, ">"); i >= 0 {
+		ident = ident[:i+1]
+	}
+	return ident, nil
+}
+
+// RecentAuthors returns up to max distinct authors ("Name <email>", after
+// .mailmap) of the most recent commits touching paths, newest first.
+func (g *Git) RecentAuthors(paths []string, max int) ([]string, error) {
+	if len(paths) == 0 || max <= 0 {
+		return nil, nil
+	}
+
+	args := withPathspec([]string{"log", "-n", "200", "--format=%aN <%aE>"}, paths)
+	out, err := g.runGit(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent authors: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var authors []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		authors = append(authors, line)
+		if len(authors) == max {
+			break
+		}
+	}
+	return authors, nil
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_AddTrailers(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	g := New(dir)
+
+	got, err := g.AddTrailers("feat(api): add login", []Trailer{{Key: "Refs", Value: "PROJ-1234"}})
+	require.NoError(t, err)
+	assert.Equal(t, "feat(api): add login\n\nRefs: PROJ-1234", got)
+
+	got, err = g.AddTrailers("fix: handle nil\n\nBody text.\n\nRefs: PROJ-1", []Trailer{
+		{Key: "Refs", Value: "PROJ-1"},
+		{Key: "Refs", Value: "PROJ-2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fix: handle nil\n\nBody text.\n\nRefs: PROJ-1\nRefs: PROJ-2", got)
+
+	got, err = g.AddTrailers("fix: x", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "fix: x", got)
+}
+
+func TestGit_IdentityAndRecentAuthors(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+	g := New(dir)
+
+	ident, err := g.Identity()
+	require.NoError(t, err)
+	assert.Equal(t, "Test User <test@example.com>", ident)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0o644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "init")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a2\n"), 0o644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com", "commit", "-m", "change a")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b2\n"), 0o644))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "-c", "user.name=Bob", "-c", "user.email=bob@example.com", "commit", "-m", "change b")
+
+	authors, err := g.RecentAuthors([]string{"a.txt"}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Jane Doe <jane@example.com>", "Test User <test@example.com>"}, authors)
+
+	authors, err = g.RecentAuthors([]string{"a.txt", "b.txt"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bob <bob@example.com>"}, authors)
+}
@@ -16,13 +16,28 @@ type ClaudeProvider struct {
 	template prompt.Template
 	apiKey   string
 	model    string
+	usage    Usage
 }
 
 type ClaudeRequest struct {
-	Model     string    `json:"model"`
-	System    string    `json:"system,omitempty"`
-	Messages  []Message `json:"messages"`
-	MaxTokens int       `json:"max_tokens"`
+	Model      string            `json:"model"`
+	System     string            `json:"system,omitempty"`
+	Messages   []Message         `json:"messages"`
+	MaxTokens  int               `json:"max_tokens"`
+	Tools      []ClaudeTool      `json:"tools,omitempty"`
+	ToolChoice *ClaudeToolChoice `json:"tool_choice,omitempty"`
+}
+
+// ClaudeTool is a tool whose input schema describes the structured response.
+type ClaudeTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type ClaudeToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
 }
 
 type Message struct {
@@ -32,8 +47,12 @@ type Message struct {
 
 type ClaudeResponse struct {
 	Content []struct {
-		Text string `json:"text"`
+		Type  string          `json:"type"`
+		Text  string          `json:"text"`
+		Input json.RawMessage `json:"input"`
 	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Usage      Usage  `json:"usage"`
 }
 
 func NewClaudeProvider(apiKey, model string) *ClaudeProvider {
@@ -53,29 +72,65 @@ func (c *ClaudeProvider) SetTemplate(template prompt.Template) {
 }
 
 func (c *ClaudeProvider) GenerateMessage(ctx context.Context, input string) (string, error) {
-	if c.apiKey == "" {
-		return "", fmt.Errorf("claude API key is required")
+	request := ClaudeRequest{MaxTokens: 150}
+	response, err := c.send(ctx, input, request)
+	if err != nil {
+		return "", err
 	}
+	return response.Content[0].Text, nil
+}
 
-	prompt := c.template.GeneratePrompt(input)
+func (c *ClaudeProvider) GenerateText(ctx context.Context, input string, maxTokens int) (string, error) {
+	response, err := c.send(ctx, input, ClaudeRequest{MaxTokens: maxTokens})
+	if err != nil {
+		return "", err
+	}
+	if response.StopReason == "max_tokens" {
+		return "", fmt.Errorf("%w (%d tokens)", ErrTruncated, maxTokens)
+	}
+	return response.Content[0].Text, nil
+}
 
+// GenerateJSON forces a call of a tool whose input schema is schema and
+// returns the tool input.
+func (c *ClaudeProvider) GenerateJSON(ctx context.Context, input string, schema prompt.JSONSchema) (string, error) {
 	request := ClaudeRequest{
-		Model:  c.model,
-		System: c.template.GetSystemPrompt(),
-		Messages: []Message{
-			{Role: "user", Content: prompt},
-		},
-		MaxTokens: 150,
+		MaxTokens:  structuredMaxTokens,
+		Tools:      []ClaudeTool{{Name: schema.Name, Description: schema.Description, InputSchema: schema.Schema}},
+		ToolChoice: &ClaudeToolChoice{Type: "tool", Name: schema.Name},
+	}
+	response, err := c.send(ctx, input, request)
+	if err != nil {
+		return "", err
+	}
+	for _, block := range response.Content {
+		if block.Type == "tool_use" {
+			return string(block.Input), nil
+		}
+	}
+	// Fall back to a JSON text answer.
+	return response.Content[0].Text, nil
+}
+
+func (c *ClaudeProvider) send(ctx context.Context, input string, request ClaudeRequest) (ClaudeResponse, error) {
+	if c.apiKey == "" {
+		return ClaudeResponse{}, fmt.Errorf("claude API key is required")
+	}
+
+	request.Model = c.model
+	request.System = c.template.GetSystemPrompt()
+	request.Messages = []Message{
+		{Role: "user", Content: c.template.GeneratePrompt(input)},
 	}
 
 	body, err := json.Marshal(request)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return ClaudeResponse{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(body))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return ClaudeResponse{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -86,7 +141,7 @@ func (c *ClaudeProvider) GenerateMessage(ctx context.Context, input string) (str
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return ClaudeResponse{}, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -95,21 +150,26 @@ func (c *ClaudeProvider) GenerateMessage(ctx context.Context, input string) (str
 		if b, err := io.ReadAll(resp.Body); err == nil {
 			body = b
 		}
-		return "", fmt.Errorf("claude API returned status %d: %s", resp.StatusCode, string(body))
+		return ClaudeResponse{}, fmt.Errorf("claude API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var response ClaudeResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return ClaudeResponse{}, fmt.Errorf("failed to decode response: %w", err)
 	}
+	c.usage.add(response.Usage.InputTokens, response.Usage.OutputTokens)
 
 	if len(response.Content) == 0 {
-		return "", fmt.Errorf("no content in response")
+		return ClaudeResponse{}, fmt.Errorf("no content in response")
 	}
 
-	return response.Content[0].Text, nil
+	return response, nil
 }
 
 func (c *ClaudeProvider) Name() string {
 	return "claude"
 }
+
+func (c *ClaudeProvider) Usage() Usage {
+	return c.usage
+}
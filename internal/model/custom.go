@@ -7,7 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/aicommit/aicommit/pkg/prompt"
@@ -19,6 +20,7 @@ type CustomProvider struct {
 	apiKey   string
 	model    string
 	url      string
+	usage    Usage
 }
 
 func NewCustomProvider(url, apiKey, model string) *CustomProvider {
@@ -35,20 +37,78 @@ func (c *CustomProvider) SetTemplate(template prompt.Template) {
 	c.template = template
 }
 
+// OllamaChatRequest is the request of Ollama's native /api/chat endpoint.
+type OllamaChatRequest struct {
+	Model    string    `json:"model"`
+	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream"`
+	// Format is a JSON schema that constrains the response.
+	Format  map[string]any `json:"format,omitempty"`
+	Options *OllamaOptions `json:"options,omitempty"`
+}
+
+type OllamaOptions struct {
+	// NumPredict is the maximum number of tokens to generate.
+	NumPredict int `json:"num_predict,omitempty"`
+}
+
+type OllamaChatResponse struct {
+	Message         Message `json:"message"`
+	DoneReason      string  `json:"done_reason"`
+	PromptEvalCount int     `json:"prompt_eval_count"`
+	EvalCount       int     `json:"eval_count"`
+}
+
 func (c *CustomProvider) GenerateMessage(ctx context.Context, input string) (string, error) {
+	return c.complete(ctx, input, nil, 0)
+}
+
+func (c *CustomProvider) GenerateText(ctx context.Context, input string, maxTokens int) (string, error) {
+	return c.complete(ctx, input, nil, maxTokens)
+}
+
+// GenerateJSON sends schema as Ollama's format for /api/chat URLs and as an
+// OpenAI json_schema response format otherwise.
+func (c *CustomProvider) GenerateJSON(ctx context.Context, input string, schema prompt.JSONSchema) (string, error) {
+	return c.complete(ctx, input, &schema, 0)
+}
+
+// isOllamaChat reports whether the URL is Ollama's native chat endpoint
+// rather than an OpenAI-compatible one.
+func (c *CustomProvider) isOllamaChat() bool {
+	u, err := url.Parse(c.url)
+	return err == nil && strings.HasSuffix(strings.TrimSuffix(u.Path, "/"), "/api/chat")
+}
+
+// complete sends the request; a response limited by maxTokens (0 for none)
+// must not be cut off.
+func (c *CustomProvider) complete(ctx context.Context, input string, schema *prompt.JSONSchema, maxTokens int) (string, error) {
 	if c.url == "" {
 		return "", fmt.Errorf("custom provider URL is required")
 	}
 
-	promptStr := c.template.GeneratePrompt(input)
+	messages := []Message{
+		{Role: "system", Content: c.template.GetSystemPrompt()},
+		{Role: "user", Content: c.template.GeneratePrompt(input)},
+	}
 
-	// Use standard OpenAI chat format as it's the most common
-	request := OpenAIRequest{
-		Model: c.model,
-		Messages: []Message{
-			{Role: "system", Content: c.template.GetSystemPrompt()},
-			{Role: "user", Content: promptStr},
-		},
+	var request any
+	if c.isOllamaChat() {
+		ollama := OllamaChatRequest{Model: c.model, Messages: messages}
+		if schema != nil {
+			ollama.Format = schema.Schema
+		}
+		if maxTokens > 0 {
+			ollama.Options = &OllamaOptions{NumPredict: maxTokens}
+		}
+		request = ollama
+	} else {
+		// Use standard OpenAI chat format as it's the most common
+		openai := OpenAIRequest{Model: c.model, Messages: messages, MaxTokens: maxTokens}
+		if schema != nil {
+			openai.ResponseFormat = jsonSchemaFormat(*schema)
+		}
+		request = openai
 	}
 
 	body, err := json.Marshal(request)
@@ -88,10 +148,26 @@ func (c *CustomProvider) GenerateMessage(ctx context.Context, input string) (str
 		return "", fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if c.isOllamaChat() {
+		var response OllamaChatResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return "", fmt.Errorf("failed to decode response: %w, body: %s", err, string(responseBody))
+		}
+		c.usage.add(response.PromptEvalCount, response.EvalCount)
+		if maxTokens > 0 && response.DoneReason == "length" {
+			return "", fmt.Errorf("%w (%d tokens)", ErrTruncated, maxTokens)
+		}
+		if response.Message.Content == "" {
+			return "", fmt.Errorf("custom provider returned empty content")
+		}
+		return response.Message.Content, nil
+	}
+
 	var response ChatCompletionResponse
 	if err := json.Unmarshal(responseBody, &response); err != nil {
 		return "", fmt.Errorf("failed to decode response: %w, body: %s", err, string(responseBody))
 	}
+	c.usage.add(response.Usage.PromptTokens, response.Usage.CompletionTokens)
 
 	if len(response.Choices) == 0 {
 		return "", fmt.Errorf("no choices in response from custom provider")
@@ -99,6 +175,9 @@ func (c *CustomProvider) GenerateMessage(ctx context.Context, input string) (str
 
 	choice := response.Choices[0]
 	content := choice.Message.Content
+	if maxTokens > 0 && choice.FinishReason == "length" {
+		return "", fmt.Errorf("%w (%d tokens)", ErrTruncated, maxTokens)
+	}
 
 	if content == "" {
 		return "", fmt.Errorf("custom provider returned empty content")
@@ -110,3 +189,7 @@ func (c *CustomProvider) GenerateMessage(ctx context.Context, input string) (str
 func (c *CustomProvider) Name() string {
 	return "custom"
 }
+
+func (c *CustomProvider) Usage() Usage {
+	return c.usage
+}
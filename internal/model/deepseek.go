@@ -16,18 +16,22 @@ type DeepSeekProvider struct {
 	template prompt.Template
 	apiKey   string
 	model    string
+	usage    Usage
 }
 
 type DeepSeekRequest struct {
-	Model     string    `json:"model"`
-	Messages  []Message `json:"messages"`
-	MaxTokens int       `json:"max_tokens"`
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	MaxTokens      int             `json:"max_tokens"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 }
 
 type DeepSeekResponse struct {
 	Choices []struct {
-		Message Message `json:"message"`
+		Message      Message `json:"message"`
+		FinishReason string  `json:"finish_reason"`
 	} `json:"choices"`
+	Usage TokenUsage `json:"usage"`
 }
 
 func NewDeepSeekProvider(apiKey, model string) *DeepSeekProvider {
@@ -47,8 +51,32 @@ func (d *DeepSeekProvider) SetTemplate(template prompt.Template) {
 }
 
 func (d *DeepSeekProvider) GenerateMessage(ctx context.Context, input string) (string, error) {
+	text, _, err := d.complete(ctx, input, nil, 150)
+	return text, err
+}
+
+func (d *DeepSeekProvider) GenerateText(ctx context.Context, input string, maxTokens int) (string, error) {
+	text, finishReason, err := d.complete(ctx, input, nil, maxTokens)
+	if err != nil {
+		return "", err
+	}
+	if finishReason == "length" {
+		return "", fmt.Errorf("%w (%d tokens)", ErrTruncated, maxTokens)
+	}
+	return text, nil
+}
+
+// GenerateJSON uses JSON mode. DeepSeek does not accept a schema, so the
+// fields are only described by the prompt.
+func (d *DeepSeekProvider) GenerateJSON(ctx context.Context, input string, schema prompt.JSONSchema) (string, error) {
+	text, _, err := d.complete(ctx, input, &ResponseFormat{Type: "json_object"}, structuredMaxTokens)
+	return text, err
+}
+
+// complete returns the response text and its finish reason.
+func (d *DeepSeekProvider) complete(ctx context.Context, input string, format *ResponseFormat, maxTokens int) (string, string, error) {
 	if d.apiKey == "" {
-		return "", fmt.Errorf("deepseek API key is required")
+		return "", "", fmt.Errorf("deepseek API key is required")
 	}
 
 	prompt := d.template.GeneratePrompt(input)
@@ -59,17 +87,18 @@ func (d *DeepSeekProvider) GenerateMessage(ctx context.Context, input string) (s
 			{Role: "system", Content: d.template.GetSystemPrompt()},
 			{Role: "user", Content: prompt},
 		},
-		MaxTokens: 150,
+		MaxTokens:      maxTokens,
+		ResponseFormat: format,
 	}
 
 	body, err := json.Marshal(request)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.deepseek.com/v1/chat/completions", bytes.NewReader(body))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -79,7 +108,7 @@ func (d *DeepSeekProvider) GenerateMessage(ctx context.Context, input string) (s
 
 	resp, err := d.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", "", fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -88,21 +117,27 @@ func (d *DeepSeekProvider) GenerateMessage(ctx context.Context, input string) (s
 		if b, err := io.ReadAll(resp.Body); err == nil {
 			body = b
 		}
-		return "", fmt.Errorf("deepseek API returned status %d: %s", resp.StatusCode, string(body))
+		return "", "", fmt.Errorf("deepseek API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var response DeepSeekResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return "", "", fmt.Errorf("failed to decode response: %w", err)
 	}
+	d.usage.add(response.Usage.PromptTokens, response.Usage.CompletionTokens)
 
 	if len(response.Choices) == 0 {
-		return "", fmt.Errorf("no choices in response")
+		return "", "", fmt.Errorf("no choices in response")
 	}
 
-	return response.Choices[0].Message.Content, nil
+	choice := response.Choices[0]
+	return choice.Message.Content, choice.FinishReason, nil
 }
 
 func (d *DeepSeekProvider) Name() string {
 	return "deepseek"
 }
+
+func (d *DeepSeekProvider) Usage() Usage {
+	return d.usage
+}
@@ -19,13 +19,41 @@ type OpenAIProvider struct {
 	template prompt.Template
 	apiKey   string
 	model    string
+	usage    Usage
 }
 
 type OpenAIRequest struct {
-	Model               string    `json:"model"`
-	Messages            []Message `json:"messages"`
-	MaxTokens           int       `json:"max_tokens,omitempty"`
-	MaxCompletionTokens int       `json:"max_completion_tokens,omitempty"`
+	Model               string          `json:"model"`
+	Messages            []Message       `json:"messages"`
+	MaxTokens           int             `json:"max_tokens,omitempty"`
+	MaxCompletionTokens int             `json:"max_completion_tokens,omitempty"`
+	ResponseFormat      *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat constrains chat completions to JSON ("json_object") or to a
+// JSON schema ("json_schema").
+type ResponseFormat struct {
+	Type       string              `json:"type"`
+	JSONSchema *ResponseJSONSchema `json:"json_schema,omitempty"`
+}
+
+type ResponseJSONSchema struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Schema      map[string]any `json:"schema"`
+	Strict      bool           `json:"strict"`
+}
+
+func jsonSchemaFormat(schema prompt.JSONSchema) *ResponseFormat {
+	return &ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &ResponseJSONSchema{
+			Name:        schema.Name,
+			Description: schema.Description,
+			Schema:      schema.Schema,
+			Strict:      true,
+		},
+	}
 }
 
 // OpenAIListModelsResponse represents the response from OpenAI models list API
@@ -216,6 +244,21 @@ func (o *OpenAIProvider) checkModelExists(model string) error {
 }
 
 func (o *OpenAIProvider) GenerateMessage(ctx context.Context, input string) (string, error) {
+	return o.complete(ctx, input, nil, 0)
+}
+
+func (o *OpenAIProvider) GenerateText(ctx context.Context, input string, maxTokens int) (string, error) {
+	return o.complete(ctx, input, nil, maxTokens)
+}
+
+// GenerateJSON uses structured outputs to return a document matching schema.
+func (o *OpenAIProvider) GenerateJSON(ctx context.Context, input string, schema prompt.JSONSchema) (string, error) {
+	return o.complete(ctx, input, jsonSchemaFormat(schema), 0)
+}
+
+// complete sends the request; a response limited by maxTokens (0 for none)
+// must not be cut off.
+func (o *OpenAIProvider) complete(ctx context.Context, input string, format *ResponseFormat, maxTokens int) (string, error) {
 	if o.apiKey == "" {
 		return "", fmt.Errorf("openai API key is required")
 	}
@@ -233,6 +276,8 @@ func (o *OpenAIProvider) GenerateMessage(ctx context.Context, input string) (str
 			{Role: "system", Content: o.template.GetSystemPrompt()},
 			{Role: "user", Content: prompt},
 		},
+		MaxCompletionTokens: maxTokens,
+		ResponseFormat:      format,
 	}
 
 	body, err := json.Marshal(request)
@@ -274,12 +319,16 @@ func (o *OpenAIProvider) GenerateMessage(ctx context.Context, input string) (str
 	if err := json.Unmarshal(responseBody, &response); err != nil {
 		return "", fmt.Errorf("failed to decode response: %w, body: %s", err, string(responseBody))
 	}
+	o.usage.add(response.Usage.PromptTokens, response.Usage.CompletionTokens)
 
 	if len(response.Choices) == 0 {
 		return "", fmt.Errorf("no choices in response (model: %s)", o.model)
 	}
 
 	choice := response.Choices[0]
+	if maxTokens > 0 && choice.FinishReason == "length" {
+		return "", fmt.Errorf("%w (%d tokens, model: %s)", ErrTruncated, maxTokens, o.model)
+	}
 	return o.processResponse(choice)
 }
 
@@ -313,3 +362,7 @@ func (o *OpenAIProvider) processResponse(choice Choice) (string, error) {
 func (o *OpenAIProvider) Name() string {
 	return "openai"
 }
+
+func (o *OpenAIProvider) Usage() Usage {
+	return o.usage
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aicommit/aicommit/pkg/prompt"
 )
@@ -11,3 +12,44 @@ type Provider interface {
 	SetTemplate(template prompt.Template)
 	Name() string
 }
+
+// structuredMaxTokens is the response limit for JSON responses, which need
+// room for the field names and escaping around the message.
+const structuredMaxTokens = 1024
+
+// StructuredProvider is implemented by providers that can constrain the
+// response to a JSON document.
+type StructuredProvider interface {
+	Provider
+	// GenerateJSON returns the response as a JSON document that matches schema.
+	GenerateJSON(ctx context.Context, input string, schema prompt.JSONSchema) (string, error)
+}
+
+// TextProvider is implemented by providers that can return long-form text,
+// such as pull request descriptions, with a response limit per call.
+type TextProvider interface {
+	Provider
+	// GenerateText returns a response of at most maxTokens tokens. A response
+	// that reaches the limit fails with ErrTruncated.
+	GenerateText(ctx context.Context, input string, maxTokens int) (string, error)
+}
+
+// ErrTruncated is returned when a response was cut off at its token limit.
+var ErrTruncated = errors.New("response was cut off at the token limit")
+
+// Usage is the number of tokens a provider has used so far.
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+func (u *Usage) add(input, output int) {
+	u.InputTokens += input
+	u.OutputTokens += output
+}
+
+// UsageReporter is implemented by providers that report token usage. The
+// usage adds up all requests, including repair attempts.
+type UsageReporter interface {
+	Usage() Usage
+}
@@ -0,0 +1,125 @@
+// Package monorepo maps repository paths to packages, Conventional Commit
+// scopes and tag prefixes.
+package monorepo
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Rule matches package directories with a glob such as "services/*".
+type Rule struct {
+	Pattern string
+	// Scope defaults to "{name}".
+	Scope string
+	// TagPrefix defaults to "{dir}/".
+	TagPrefix string
+}
+
+// Package is a directory matched by a Rule.
+type Package struct {
+	Dir       string
+	Scope     string
+	TagPrefix string
+}
+
+// Layout resolves files to packages.
+type Layout struct {
+	rules []Rule
+}
+
+// New creates a layout. Rules are tried in order; the first match wins.
+func New(rules []Rule) (*Layout, error) {
+	l := &Layout{}
+	for _, r := range rules {
+		r.Pattern = strings.Trim(strings.TrimSpace(r.Pattern), "/")
+		if r.Pattern == "" {
+			return nil, fmt.Errorf("monorepo package path cannot be empty")
+		}
+		if _, err := path.Match(r.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid monorepo package path %q: %w", r.Pattern, err)
+		}
+		l.rules = append(l.rules, r)
+	}
+	return l, nil
+}
+
+// IsEmpty reports whether no package rules are configured.
+func (l *Layout) IsEmpty() bool {
+	return l == nil || len(l.rules) == 0
+}
+
+// PackageFor returns the package containing file (a slash-separated path
+// relative to the repository root).
+func (l *Layout) PackageFor(file string) (Package, bool) {
+	if l.IsEmpty() {
+		return Package{}, false
+	}
+
+	segments := strings.Split(strings.Trim(file, "/"), "/")
+	for _, r := range l.rules {
+		n := strings.Count(r.Pattern, "/") + 1
+		if len(segments) < n {
+			continue
+		}
+		dir := strings.Join(segments[:n], "/")
+		if ok, _ := path.Match(r.Pattern, dir); ok {
+			return r.packageFor(dir), true
+		}
+	}
+	return Package{}, false
+}
+
+// Lookup returns the package rooted at dir, e.g. "libs/auth".
+func (l *Layout) Lookup(dir string) (Package, error) {
+	dir = strings.Trim(strings.TrimSpace(dir), "/")
+	if l.IsEmpty() {
+		return Package{}, fmt.Errorf("no monorepo packages configured")
+	}
+	for _, r := range l.rules {
+		if ok, _ := path.Match(r.Pattern, dir); ok {
+			return r.packageFor(dir), nil
+		}
+	}
+	return Package{}, fmt.Errorf("%s does not match any configured monorepo package path", dir)
+}
+
+// Scopes returns the sorted, unique scopes of the packages touched by files.
+// Files outside every package are ignored.
+func (l *Layout) Scopes(files []string) []string {
+	seen := make(map[string]bool)
+	var scopes []string
+	for _, f := range files {
+		pkg, ok := l.PackageFor(f)
+		if !ok || seen[pkg.Scope] {
+			continue
+		}
+		seen[pkg.Scope] = true
+		scopes = append(scopes, pkg.Scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+func (r Rule) packageFor(dir string) Package {
+	scope := r.Scope
+	if scope == "" {
+		scope = "{name}"
+	}
+	prefix := r.TagPrefix
+	if prefix == "" {
+		prefix = "{dir}/"
+	}
+	return Package{
+		Dir:       dir,
+		Scope:     expand(scope, dir),
+		TagPrefix: expand(prefix, dir),
+	}
+}
+
+func expand(s, dir string) string {
+	s = strings.ReplaceAll(s, "{dir}", dir)
+	return strings.ReplaceAll(s, "{name}", path.Base(dir))
+}
@@ -0,0 +1,61 @@
+package monorepo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayout(t *testing.T) {
+	l, err := New([]Rule{
+		{Pattern: "services/*"},
+		{Pattern: "libs/*", Scope: "lib-{name}", TagPrefix: "{name}-"},
+		{Pattern: "tools", Scope: "tooling"},
+	})
+	require.NoError(t, err)
+	assert.False(t, l.IsEmpty())
+
+	pkg, ok := l.PackageFor("services/api/main.go")
+	require.True(t, ok)
+	assert.Equal(t, Package{Dir: "services/api", Scope: "api", TagPrefix: "services/api/"}, pkg)
+
+	pkg, ok = l.PackageFor("libs/auth/token/jwt.go")
+	require.True(t, ok)
+	assert.Equal(t, Package{Dir: "libs/auth", Scope: "lib-auth", TagPrefix: "auth-"}, pkg)
+
+	pkg, ok = l.PackageFor("tools/gen.go")
+	require.True(t, ok)
+	assert.Equal(t, "tooling", pkg.Scope)
+
+	_, ok = l.PackageFor("README.md")
+	assert.False(t, ok)
+	_, ok = l.PackageFor("services")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"api", "lib-auth"}, l.Scopes([]string{
+		"services/api/a.go", "libs/auth/b.go", "services/api/c.go", "go.mod",
+	}))
+	assert.Empty(t, l.Scopes([]string{"go.mod"}))
+
+	pkg, err = l.Lookup("libs/auth/")
+	require.NoError(t, err)
+	assert.Equal(t, "auth-", pkg.TagPrefix)
+
+	_, err = l.Lookup("apps/web")
+	assert.Error(t, err)
+}
+
+func TestNew_InvalidRules(t *testing.T) {
+	_, err := New([]Rule{{Pattern: ""}})
+	assert.Error(t, err)
+
+	_, err = New([]Rule{{Pattern: "libs/[a"}})
+	assert.Error(t, err)
+
+	empty, err := New(nil)
+	require.NoError(t, err)
+	assert.True(t, empty.IsEmpty())
+	_, ok := empty.PackageFor("libs/a/b.go")
+	assert.False(t, ok)
+}
@@ -0,0 +1,255 @@
+// Package changelog builds Keep a Changelog sections from Conventional Commit
+// messages and inserts them into an existing CHANGELOG.md.
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// Section names used in generated releases, in output order.
+const (
+	SectionBreaking = "Breaking Changes"
+	SectionAdded    = "Added"
+	SectionChanged  = "Changed"
+	SectionFixed    = "Fixed"
+)
+
+var sectionOrder = []string{SectionBreaking, SectionAdded, SectionChanged, SectionFixed}
+
+// DefaultHeader is written when a new CHANGELOG.md is created.
+const DefaultHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+var semverWithPrefix = regexp.MustCompile(`^v\d`)
+
+// Release is one version section of a changelog.
+type Release struct {
+	Version  string
+	Date     string
+	Sections map[string][]string
+}
+
+// SectionForType maps a Conventional Commit type to a changelog section.
+// Types that are not user facing (docs, chore, ci, ...) are not listed.
+func SectionForType(commitType string) (string, bool) {
+	switch strings.ToLower(commitType) {
+	case "feat":
+		return SectionAdded, true
+	case "fix":
+		return SectionFixed, true
+	case "perf", "refactor", "revert", "deprecate", "remove", "security":
+		return SectionChanged, true
+	default:
+		return "", false
+	}
+}
+
+// NormalizeVersion strips the "v" prefix from tag names such as "v1.2.3",
+// matching the Keep a Changelog heading style.
+func NormalizeVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if semverWithPrefix.MatchString(version) {
+		return version[1:]
+	}
+	return version
+}
+
+// NewRelease groups commit messages into changelog sections. Messages that
+// are not Conventional Commits, and types without a section, are skipped
+// unless they are breaking changes.
+func NewRelease(version, date string, messages []string) *Release {
+	r := &Release{
+		Version:  NormalizeVersion(version),
+		Date:     strings.TrimSpace(date),
+		Sections: make(map[string][]string),
+	}
+
+	for _, message := range messages {
+		c, err := prompt.ParseConventionalCommit(message)
+		if err != nil {
+			continue
+		}
+
+		if c.Breaking {
+			r.add(SectionBreaking, formatEntry(c.Scope, c.BreakingNote()))
+		}
+		if section, ok := SectionForType(c.Type); ok {
+			r.add(section, formatEntry(c.Scope, c.Description))
+		}
+	}
+
+	return r
+}
+
+func (r *Release) add(section, entry string) {
+	for _, existing := range r.Sections[section] {
+		if existing == entry {
+			return
+		}
+	}
+	r.Sections[section] = append(r.Sections[section], entry)
+}
+
+func formatEntry(scope, text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "\n", " ")
+	if scope == "" {
+		return text
+	}
+	return fmt.Sprintf("**%s:** %s", scope, text)
+}
+
+// IsEmpty reports whether no commit produced a changelog entry.
+func (r *Release) IsEmpty() bool {
+	for _, entries := range r.Sections {
+		if len(entries) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Heading returns the "## [version] - date" line of the release.
+func (r *Release) Heading() string {
+	if r.Date == "" {
+		return fmt.Sprintf("## [%s]", r.Version)
+	}
+	return fmt.Sprintf("## [%s] - %s", r.Version, r.Date)
+}
+
+// Markdown renders the release as a Keep a Changelog section.
+func (r *Release) Markdown() string {
+	var b strings.Builder
+	b.WriteString(r.Heading())
+	b.WriteString("\n")
+
+	for _, section := range sectionOrder {
+		entries := r.Sections[section]
+		if len(entries) == 0 {
+			continue
+		}
+		b.WriteString("\n### ")
+		b.WriteString(section)
+		b.WriteString("\n")
+		for _, entry := range entries {
+			b.WriteString("- ")
+			b.WriteString(entry)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// CheckRewrite verifies that section, a reworded copy of r.Markdown(), keeps
+// the heading, the section headings and the number of entries in each.
+func (r *Release) CheckRewrite(section string) error {
+	lines := strings.Split(strings.TrimSpace(normalizeNewlines(section)), "\n")
+	if strings.TrimSpace(lines[0]) != r.Heading() {
+		return fmt.Errorf("changelog section does not start with %q", r.Heading())
+	}
+
+	var headings []string
+	counts := map[string]int{}
+	for _, line := range lines[1:] {
+		switch {
+		case strings.HasPrefix(line, "### "):
+			headings = append(headings, strings.TrimSpace(line[len("### "):]))
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			if len(headings) == 0 {
+				return fmt.Errorf("changelog entry %q is outside a section", line)
+			}
+			counts[headings[len(headings)-1]]++
+		}
+	}
+
+	var want []string
+	for _, name := range sectionOrder {
+		if len(r.Sections[name]) > 0 {
+			want = append(want, name)
+		}
+	}
+	if strings.Join(headings, "\n") != strings.Join(want, "\n") {
+		return fmt.Errorf("changelog sections are %q, expected %q", headings, want)
+	}
+	for _, name := range want {
+		if n := len(r.Sections[name]); counts[name] != n {
+			return fmt.Errorf("changelog section %q has %d entries, expected %d", name, counts[name], n)
+		}
+	}
+	return nil
+}
+
+// Insert adds section to an existing changelog. The section is placed after
+// the [Unreleased] block and before the most recent release, so existing
+// entries are left untouched. An empty changelog gets DefaultHeader.
+func Insert(existing, section string) (string, error) {
+	section = strings.TrimSpace(normalizeNewlines(section))
+	if section == "" {
+		return "", fmt.Errorf("changelog section is empty")
+	}
+
+	heading := strings.SplitN(section, "\n", 2)[0]
+	if !strings.HasPrefix(heading, "## ") {
+		return "", fmt.Errorf("changelog section must start with a \"## \" heading")
+	}
+
+	existing = normalizeNewlines(existing)
+	if strings.TrimSpace(existing) == "" {
+		return DefaultHeader + "\n" + section + "\n", nil
+	}
+
+	lines := strings.Split(existing, "\n")
+	version := headingVersion(heading)
+	insertAt := -1
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		v := headingVersion(line)
+		if version != "" && strings.EqualFold(v, version) {
+			return "", fmt.Errorf("changelog already contains a section for %s", version)
+		}
+		if insertAt == -1 && !strings.EqualFold(v, "unreleased") {
+			insertAt = i
+		}
+	}
+
+	if insertAt == -1 {
+		return strings.TrimRight(existing, "\n") + "\n\n" + section + "\n", nil
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[:insertAt], "\n"))
+	if insertAt > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(section)
+	b.WriteString("\n\n")
+	b.WriteString(strings.Join(lines[insertAt:], "\n"))
+	return b.String(), nil
+}
+
+// headingVersion extracts "1.2.3" from "## [1.2.3] - 2024-01-01".
+func headingVersion(line string) string {
+	line = strings.TrimSpace(strings.TrimPrefix(line, "## "))
+	if strings.HasPrefix(line, "[") {
+		if end := strings.Index(line, "]"); end > 0 {
+			return line[1:end]
+		}
+	}
+	return ""
+}
+
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
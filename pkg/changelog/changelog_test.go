@@ -0,0 +1,108 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRelease(t *testing.T) {
+	r := NewRelease("v1.4.0", "2024-05-01", []string{
+		"feat(auth): add SSO login",
+		"fix: handle empty config",
+		"fix: handle empty config",
+		"perf(git): cache rev-parse results",
+		"docs: update readme",
+		"Merge branch 'main' into feature",
+		"refactor(api)!: rename endpoints\n\nBREAKING CHANGE: /v1/users is now /v1/accounts",
+	})
+
+	assert.Equal(t, "1.4.0", r.Version)
+	assert.False(t, r.IsEmpty())
+
+	want := `## [1.4.0] - 2024-05-01
+
+### Breaking Changes
+- **api:** /v1/users is now /v1/accounts
+
+### Added
+- **auth:** add SSO login
+
+### Changed
+- **git:** cache rev-parse results
+- **api:** rename endpoints
+
+### Fixed
+- handle empty config
+`
+	assert.Equal(t, want, r.Markdown())
+
+	empty := NewRelease("2.0.0", "", []string{"chore: bump deps", "wip"})
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, "## [2.0.0]", empty.Heading())
+}
+
+func TestRelease_CheckRewrite(t *testing.T) {
+	r := NewRelease("1.1.0", "2024-02-01", []string{"feat(auth): add login", "feat: add logout", "fix: handle empty diff"})
+	require.NoError(t, r.CheckRewrite(r.Markdown()))
+
+	reworded := "## [1.1.0] - 2024-02-01\n\n### Added\n- **auth:** Users can log in\n- Users can log out\n\n### Fixed\n- Empty diffs no longer fail\n"
+	assert.NoError(t, r.CheckRewrite(reworded))
+
+	dropped := "## [1.1.0] - 2024-02-01\n\n### Added\n- Users can log in and out\n\n### Fixed\n- Empty diffs no longer fail\n"
+	assert.EqualError(t, r.CheckRewrite(dropped), `changelog section "Added" has 1 entries, expected 2`)
+
+	missing := "## [1.1.0] - 2024-02-01\n\n### Added\n- Users can log in\n- Users can log out\n"
+	assert.Error(t, r.CheckRewrite(missing))
+
+	assert.Error(t, r.CheckRewrite("## [1.2.0]\n\n### Added\n- Users can log in\n- Users can log out\n\n### Fixed\n- Empty diffs\n"))
+}
+
+func TestInsert(t *testing.T) {
+	existing := `# Changelog
+
+Intro text.
+
+## [Unreleased]
+
+### Added
+- Pending thing
+
+## [1.0.0] - 2024-01-01
+
+### Added
+- Initial release
+
+[1.0.0]: https://example.com/v1.0.0
+`
+	section := "## [1.1.0] - 2024-02-01\n\n### Fixed\n- A bug\n"
+
+	got, err := Insert(existing, section)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(got, "# Changelog\n\nIntro text.\n\n## [Unreleased]\n\n### Added\n- Pending thing\n\n## [1.1.0] - 2024-02-01\n\n### Fixed\n- A bug\n\n## [1.0.0] - 2024-01-01\n"), got)
+	assert.True(t, strings.HasSuffix(got, "- Initial release\n\n[1.0.0]: https://example.com/v1.0.0\n"), got)
+
+	_, err = Insert(got, "## [1.1.0]\n\n### Added\n- Again\n")
+	assert.Error(t, err, "duplicate version must be rejected")
+
+	created, err := Insert("", section)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created, DefaultHeader+"\n## [1.1.0]"), created)
+
+	appended, err := Insert("# Changelog\n\n## [Unreleased]\n", section)
+	require.NoError(t, err)
+	assert.Equal(t, "# Changelog\n\n## [Unreleased]\n\n"+strings.TrimSpace(section)+"\n", appended)
+
+	_, err = Insert(existing, "no heading")
+	assert.Error(t, err)
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	assert.Equal(t, "1.2.3", NormalizeVersion("v1.2.3"))
+	assert.Equal(t, "1.2.3", NormalizeVersion("1.2.3"))
+	assert.Equal(t, "libs/auth/v1.0.0", NormalizeVersion("libs/auth/v1.0.0"))
+	assert.Equal(t, "vNext", NormalizeVersion("vNext"))
+}
@@ -0,0 +1,412 @@
+// Package commitlint translates commitlint configurations into aicommit's
+// commit message rules. Only the JSON and YAML forms are supported; JavaScript
+// configs cannot be evaluated.
+package commitlint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"gopkg.in/yaml.v3"
+)
+
+// ConventionalPreset is the name of the preset whose defaults are built in.
+const ConventionalPreset = "@commitlint/config-conventional"
+
+var (
+	// ErrNotFound is returned by Discover when dir has no commitlint config.
+	ErrNotFound = errors.New("no commitlint config found")
+	// ErrUnsupportedFormat is returned for JavaScript and TypeScript configs.
+	ErrUnsupportedFormat = errors.New("JavaScript commitlint configs are not supported; use .commitlintrc.json or .commitlintrc.yaml")
+)
+
+// configFiles are searched in the same order as commitlint does.
+var configFiles = []string{
+	".commitlintrc",
+	".commitlintrc.json",
+	".commitlintrc.yaml",
+	".commitlintrc.yml",
+	".commitlintrc.js",
+	".commitlintrc.cjs",
+	".commitlintrc.mjs",
+	".commitlintrc.ts",
+	"commitlint.config.js",
+	"commitlint.config.cjs",
+	"commitlint.config.mjs",
+	"commitlint.config.ts",
+}
+
+// conventionalRules are the rules of @commitlint/config-conventional.
+var conventionalRules = map[string]Rule{
+	"body-leading-blank":     {Level: 1, Applicable: "always"},
+	"body-max-line-length":   {Level: 2, Applicable: "always", Value: 100},
+	"header-max-length":      {Level: 2, Applicable: "always", Value: 100},
+	"subject-case":           {Level: 2, Applicable: "never", Value: []any{"sentence-case", "start-case", "pascal-case", "upper-case"}},
+	"subject-empty":          {Level: 2, Applicable: "never"},
+	"subject-full-stop":      {Level: 2, Applicable: "never", Value: "."},
+	"type-case":              {Level: 2, Applicable: "always", Value: "lower-case"},
+	"type-empty":             {Level: 2, Applicable: "never"},
+	"type-enum":              {Level: 2, Applicable: "always", Value: []any{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}},
+	"footer-leading-blank":   {Level: 1, Applicable: "always"},
+	"footer-max-line-length": {Level: 2, Applicable: "always", Value: 100},
+}
+
+// Rule is a commitlint rule: [level, applicable, value].
+type Rule struct {
+	// Level is 0 (disabled), 1 (warning) or 2 (error).
+	Level      int
+	Applicable string
+	Value      any
+}
+
+// Config is a parsed commitlint configuration.
+type Config struct {
+	Extends []string
+	Rules   map[string]Rule
+}
+
+// Find returns the commitlint config in dir, including a "commitlint" key in
+// package.json. ok is false when there is none.
+func Find(dir string) (path string, ok bool) {
+	for _, name := range configFiles {
+		p := filepath.Join(dir, name)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p, true
+		}
+	}
+
+	p := filepath.Join(dir, "package.json")
+	data, err := os.ReadFile(p) // #nosec G304 -- Fixed file name in the repository.
+	if err != nil {
+		return "", false
+	}
+	var pkg map[string]json.RawMessage
+	if json.Unmarshal(data, &pkg) != nil {
+		return "", false
+	}
+	if _, ok := pkg["commitlint"]; ok {
+		return p, true
+	}
+	return "", false
+}
+
+// Load reads a JSON or YAML commitlint config, or the "commitlint" key of a
+// package.json.
+func Load(path string) (Config, error) {
+	switch filepath.Ext(path) {
+	case ".js", ".cjs", ".mjs", ".ts":
+		return Config{}, fmt.Errorf("%s: %w", path, ErrUnsupportedFormat)
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- Path comes from the config or a fixed file name.
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read commitlint config: %w", err)
+	}
+
+	if filepath.Base(path) == "package.json" {
+		var pkg struct {
+			Commitlint json.RawMessage `json:"commitlint"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if len(pkg.Commitlint) == 0 {
+			return Config{}, fmt.Errorf("%s has no commitlint key", path)
+		}
+		data = pkg.Commitlint
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse parses a commitlint config in JSON or YAML.
+func Parse(data []byte) (Config, error) {
+	// YAML is a superset of JSON, so one decoder handles both forms.
+	var raw struct {
+		Extends any            `yaml:"extends"`
+		Rules   map[string]any `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return Config{}, err
+	}
+
+	cfg := Config{Rules: make(map[string]Rule, len(raw.Rules))}
+	switch e := raw.Extends.(type) {
+	case nil:
+	case string:
+		cfg.Extends = []string{e}
+	case []any:
+		for _, item := range e {
+			s, ok := item.(string)
+			if !ok {
+				return Config{}, fmt.Errorf("extends must be a string or a list of strings")
+			}
+			cfg.Extends = append(cfg.Extends, s)
+		}
+	default:
+		return Config{}, fmt.Errorf("extends must be a string or a list of strings")
+	}
+
+	for name, v := range raw.Rules {
+		rule, err := parseRule(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("rule %s: %w", name, err)
+		}
+		cfg.Rules[name] = rule
+	}
+	return cfg, nil
+}
+
+func parseRule(v any) (Rule, error) {
+	items, ok := v.([]any)
+	if !ok || len(items) == 0 {
+		return Rule{}, fmt.Errorf("expected [level, applicable, value]")
+	}
+	level, ok := items[0].(int)
+	if !ok || level < 0 || level > 2 {
+		return Rule{}, fmt.Errorf("level must be 0, 1 or 2")
+	}
+
+	rule := Rule{Level: level, Applicable: "always"}
+	if len(items) > 1 {
+		applicable, ok := items[1].(string)
+		if !ok || (applicable != "always" && applicable != "never") {
+			return Rule{}, fmt.Errorf(`applicable must be "always" or "never"`)
+		}
+		rule.Applicable = applicable
+	}
+	if len(items) > 2 {
+		rule.Value = items[2]
+	}
+	return rule, nil
+}
+
+// Apply translates cfg onto base. Rules set by cfg replace the corresponding
+// settings of base. It returns the names of the explicitly configured rules
+// that have no aicommit equivalent; extended presets other than
+// @commitlint/config-conventional are reported as "extends:<name>".
+func (c Config) Apply(base prompt.Rules) (prompt.Rules, []string) {
+	rules := base
+	rules.Severities = make(map[string]prompt.Severity, len(base.Severities))
+	for k, v := range base.Severities {
+		rules.Severities[k] = v
+	}
+
+	var unsupported []string
+	for _, e := range c.Extends {
+		if e != ConventionalPreset && e != "conventional" && e != "config-conventional" {
+			unsupported = append(unsupported, "extends:"+e)
+			continue
+		}
+		for name, rule := range conventionalRules {
+			applyRule(&rules, name, rule)
+		}
+	}
+
+	names := make([]string, 0, len(c.Rules))
+	for name := range c.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !applyRule(&rules, name, c.Rules[name]) {
+			unsupported = append(unsupported, name)
+		}
+	}
+	return rules, unsupported
+}
+
+// ruleTargets lists the aicommit rules each supported commitlint rule maps to.
+// Rules enforced by the header format map to nothing.
+var ruleTargets = map[string][]string{
+	"type-empty":           nil,
+	"subject-empty":        nil,
+	"header-trim":          nil,
+	"type-enum":            {prompt.RuleTypeEnum},
+	"type-case":            {prompt.RuleTypeCase},
+	"scope-enum":           {prompt.RuleScopeEnum},
+	"scope-empty":          {prompt.RuleScopeEmpty},
+	"header-max-length":    {prompt.RuleHeaderMaxLength},
+	"subject-case":         {prompt.RuleSubjectCase},
+	"subject-full-stop":    {prompt.RuleSubjectFullStop},
+	"body-leading-blank":   {prompt.RuleBodyLeadingBlank},
+	"body-max-line-length": {prompt.RuleBodyMaxLineLength},
+	"trailer-exists":       {prompt.RuleTrailerRequired, prompt.RuleTrailerForbidden},
+}
+
+func severity(level int) prompt.Severity {
+	switch level {
+	case 0:
+		return prompt.SeverityOff
+	case 1:
+		return prompt.SeverityWarning
+	default:
+		return prompt.SeverityError
+	}
+}
+
+// applyRule maps one commitlint rule and reports whether it is supported.
+func applyRule(rules *prompt.Rules, name string, rule Rule) bool {
+	if rule.Level == 0 {
+		// A disabled rule needs no value; turn off whatever it maps to.
+		targets, ok := ruleTargets[name]
+		for _, target := range targets {
+			rules.Severities[target] = prompt.SeverityOff
+		}
+		return ok
+	}
+
+	always := rule.Applicable != "never"
+	set := func(target string) bool {
+		rules.Severities[target] = severity(rule.Level)
+		return true
+	}
+
+	switch name {
+	case "type-empty", "subject-empty", "header-trim":
+		// Always enforced by the Conventional Commits header format.
+		return !always
+	case "type-enum":
+		types, ok := stringList(rule.Value)
+		if !always || !ok {
+			return false
+		}
+		rules.Types = types
+		return set(prompt.RuleTypeEnum)
+	case "type-case":
+		if !always || rule.Value != "lower-case" {
+			return false
+		}
+		return set(prompt.RuleTypeCase)
+	case "scope-enum":
+		scopes, ok := stringList(rule.Value)
+		if !always || !ok {
+			return false
+		}
+		rules.Scopes = scopes
+		return set(prompt.RuleScopeEnum)
+	case "scope-empty":
+		if always {
+			return false
+		}
+		rules.ScopeRequired = rule.Level > 0
+		return set(prompt.RuleScopeEmpty)
+	case "header-max-length":
+		n, ok := rule.Value.(int)
+		if !always || !ok {
+			return false
+		}
+		rules.HeaderMaxLength = n
+		return set(prompt.RuleHeaderMaxLength)
+	case "subject-case":
+		c, ok := subjectCase(rule)
+		if !ok {
+			return false
+		}
+		rules.SubjectCase = c
+		return set(prompt.RuleSubjectCase)
+	case "subject-full-stop":
+		if always || (rule.Value != nil && rule.Value != ".") {
+			return false
+		}
+		rules.SubjectNoPeriod = rule.Level > 0
+		return set(prompt.RuleSubjectFullStop)
+	case "body-leading-blank":
+		if !always {
+			return false
+		}
+		return set(prompt.RuleBodyLeadingBlank)
+	case "body-max-line-length":
+		n, ok := rule.Value.(int)
+		if !always || !ok {
+			return false
+		}
+		rules.BodyMaxLineLength = n
+		return set(prompt.RuleBodyMaxLineLength)
+	case "trailer-exists":
+		token, ok := rule.Value.(string)
+		if !ok {
+			return false
+		}
+		token = strings.TrimSuffix(strings.TrimSpace(token), ":")
+		if always {
+			rules.RequiredTrailers = append(rules.RequiredTrailers, token)
+			return set(prompt.RuleTrailerRequired)
+		}
+		rules.ForbiddenTrailers = append(rules.ForbiddenTrailers, token)
+		return set(prompt.RuleTrailerForbidden)
+	case "footer-leading-blank", "footer-max-line-length":
+		// Footers are not checked; ignore the preset values quietly.
+		return false
+	}
+	return false
+}
+
+// subjectCase maps the common subject-case settings to lower or sentence case.
+func subjectCase(rule Rule) (string, bool) {
+	cases, ok := stringList(rule.Value)
+	if !ok {
+		s, isString := rule.Value.(string)
+		if !isString {
+			return "", false
+		}
+		cases = []string{s}
+	}
+
+	if rule.Applicable != "never" {
+		switch {
+		case len(cases) == 1 && cases[0] == "lower-case":
+			return prompt.SubjectCaseLower, true
+		case len(cases) == 1 && cases[0] == "sentence-case":
+			return prompt.SubjectCaseSentence, true
+		}
+		return "", false
+	}
+
+	// "never sentence-case/start-case/..." effectively requires lower case.
+	for _, c := range cases {
+		if c == "lower-case" {
+			return "", false
+		}
+	}
+	return prompt.SubjectCaseLower, true
+}
+
+func stringList(v any) ([]string, bool) {
+	items, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, s)
+	}
+	return out, true
+}
+
+// Discover finds and loads the commitlint config in dir.
+func Discover(dir string) (Config, string, error) {
+	path, ok := Find(dir)
+	if !ok {
+		return Config{}, "", ErrNotFound
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		return Config{}, path, err
+	}
+	return cfg, path, nil
+}
@@ -0,0 +1,135 @@
+package commitlint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_JSONAndYAML(t *testing.T) {
+	jsonCfg, err := Parse([]byte(`{
+  "extends": ["@commitlint/config-conventional"],
+  "rules": {
+    "type-enum": [2, "always", ["feat", "fix"]],
+    "header-max-length": [1, "always", 72],
+    "scope-empty": [2, "never"]
+  }
+}`))
+	require.NoError(t, err)
+
+	yamlCfg, err := Parse([]byte(`extends: "@commitlint/config-conventional"
+rules:
+  type-enum: [2, always, [feat, fix]]
+  header-max-length: [1, always, 72]
+  scope-empty:
+    - 2
+    - never
+`))
+	require.NoError(t, err)
+	assert.Equal(t, jsonCfg, yamlCfg)
+	assert.Equal(t, []string{ConventionalPreset}, jsonCfg.Extends)
+	assert.Equal(t, Rule{Level: 1, Applicable: "always", Value: 72}, jsonCfg.Rules["header-max-length"])
+
+	_, err = Parse([]byte(`{"rules": {"type-enum": [3, "always", []]}}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`{"rules": {"type-enum": "feat"}}`))
+	assert.Error(t, err)
+}
+
+func TestConfig_Apply(t *testing.T) {
+	cfg, err := Parse([]byte(`{
+  "extends": ["@commitlint/config-conventional", "@acme/commitlint-config"],
+  "rules": {
+    "type-enum": [2, "always", ["feat", "fix", "chore"]],
+    "scope-enum": [2, "always", ["api", "cli"]],
+    "header-max-length": [1, "always", 72],
+    "body-leading-blank": [0],
+    "trailer-exists": [2, "always", "Signed-off-by:"],
+    "references-empty": [2, "never"]
+  }
+}`))
+	require.NoError(t, err)
+
+	rules, unsupported := cfg.Apply(prompt.Rules{BreakingConsistency: true})
+	assert.Equal(t, []string{"extends:@acme/commitlint-config", "references-empty"}, unsupported)
+	assert.Equal(t, []string{"feat", "fix", "chore"}, rules.Types)
+	assert.Equal(t, []string{"api", "cli"}, rules.Scopes)
+	assert.Equal(t, 72, rules.HeaderMaxLength)
+	assert.Equal(t, 100, rules.BodyMaxLineLength)
+	assert.Equal(t, prompt.SubjectCaseLower, rules.SubjectCase)
+	assert.True(t, rules.SubjectNoPeriod)
+	assert.True(t, rules.BreakingConsistency)
+	assert.Equal(t, []string{"Signed-off-by"}, rules.RequiredTrailers)
+	assert.Equal(t, prompt.SeverityWarning, rules.Severities[prompt.RuleHeaderMaxLength])
+	assert.Equal(t, prompt.SeverityOff, rules.Severities[prompt.RuleBodyLeadingBlank])
+
+	v := prompt.LintCommitMessage("docs: Update readme.", rules)
+	names := make([]string, 0, len(v))
+	for _, violation := range v {
+		names = append(names, violation.Rule)
+	}
+	assert.Equal(t, []string{prompt.RuleTypeEnum, prompt.RuleSubjectCase, prompt.RuleSubjectFullStop, prompt.RuleTrailerRequired}, names)
+}
+
+func TestConfig_ApplyConventionalPreset(t *testing.T) {
+	rules, unsupported := Config{Extends: []string{ConventionalPreset}}.Apply(prompt.Rules{})
+	assert.Empty(t, unsupported)
+	assert.Contains(t, rules.Types, "feat")
+	assert.Contains(t, rules.Types, "revert")
+	assert.Equal(t, 100, rules.HeaderMaxLength)
+	assert.Equal(t, prompt.SeverityError, rules.Severities[prompt.RuleTypeCase])
+	assert.Empty(t, prompt.LintCommitMessage("feat(api): add JWT support", rules))
+}
+
+func TestConfig_ApplyDisablesPresetRules(t *testing.T) {
+	cfg, err := Parse([]byte(`{
+  "extends": ["@commitlint/config-conventional"],
+  "rules": {
+    "subject-case": [0],
+    "type-enum": [0],
+    "header-max-length": [0]
+  }
+}`))
+	require.NoError(t, err)
+
+	rules, unsupported := cfg.Apply(prompt.Rules{})
+	assert.Empty(t, unsupported)
+	assert.Equal(t, prompt.SeverityOff, rules.Severities[prompt.RuleSubjectCase])
+	assert.Equal(t, prompt.SeverityOff, rules.Severities[prompt.RuleTypeEnum])
+	assert.Equal(t, prompt.SeverityOff, rules.Severities[prompt.RuleHeaderMaxLength])
+	assert.Empty(t, prompt.LintCommitMessage("fix: Fix the URL parser", rules))
+	assert.Empty(t, prompt.LintCommitMessage("wip: Fix the URL parser", rules))
+}
+
+func TestFindAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	_, ok := Find(dir)
+	assert.False(t, ok)
+	_, _, err := Discover(dir)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	pkgJSON := `{"name": "app", "commitlint": {"extends": ["@commitlint/config-conventional"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(pkgJSON), 0o644))
+	cfg, path, err := Discover(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "package.json"), path)
+	assert.Equal(t, []string{ConventionalPreset}, cfg.Extends)
+
+	yamlPath := filepath.Join(dir, ".commitlintrc.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte("rules:\n  header-max-length: [2, always, 50]\n"), 0o644))
+	cfg, path, err = Discover(dir)
+	require.NoError(t, err)
+	assert.Equal(t, yamlPath, path)
+	assert.Equal(t, 50, cfg.Rules["header-max-length"].Value)
+
+	jsPath := filepath.Join(dir, ".commitlintrc.js")
+	require.NoError(t, os.WriteFile(jsPath, []byte("module.exports = {}\n"), 0o644))
+	require.NoError(t, os.Remove(yamlPath))
+	_, path, err = Discover(dir)
+	assert.Equal(t, jsPath, path)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
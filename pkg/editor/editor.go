@@ -4,11 +4,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 )
 
-// Open opens the content in the specified editor or system default.
-// It returns the edited content.
+// Open opens the content in editorCmd, or the editor chosen by Resolve when
+// it is empty, and returns the edited content.
 func Open(content string, editorCmd string) (string, error) {
 	// Create a temporary file
 	tmpFile, err := os.CreateTemp("", "aicommit-*.txt")
@@ -25,39 +26,12 @@ func Open(content string, editorCmd string) (string, error) {
 		return "", fmt.Errorf("failed to close temp file: %w", err)
 	}
 
-	// Determine editor
-	if editorCmd == "" {
-		editorCmd = os.Getenv("EDITOR")
-		if editorCmd == "" {
-			editorCmd = os.Getenv("VISUAL")
-			if editorCmd == "" {
-				// Default fallbacks
-				if _, err := exec.LookPath("nvim"); err == nil {
-					editorCmd = "nvim"
-				} else if _, err := exec.LookPath("vim"); err == nil {
-					editorCmd = "vim"
-				} else if _, err := exec.LookPath("nano"); err == nil {
-					editorCmd = "nano"
-				} else if _, err := exec.LookPath("vi"); err == nil {
-					editorCmd = "vi"
-				} else {
-					return "", fmt.Errorf("no editor found. Please set EDITOR environment variable or configure 'editor' in config file")
-				}
-			}
-		}
-	}
-
-	// Open editor
-	parts := strings.Fields(editorCmd)
-	if len(parts) == 0 {
-		return "", fmt.Errorf("invalid editor command")
+	editorCmd, err = Resolve(editorCmd, "")
+	if err != nil {
+		return "", err
 	}
 
-	path := parts[0]
-	args := parts[1:]
-	args = append(args, tmpFile.Name())
-
-	cmd := exec.Command(path, args...)
+	cmd := command(editorCmd, tmpFile.Name())
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -74,3 +48,37 @@ func Open(content string, editorCmd string) (string, error) {
 
 	return string(newContent), nil
 }
+
+// Resolve picks the editor command with git's precedence, preceded by
+// aicommit's own editor setting: configured, GIT_EDITOR, coreEditor (git's
+// core.editor), VISUAL (unless TERM is dumb), EDITOR, and finally the first of
+// nvim, vim, nano and vi found on PATH.
+func Resolve(configured, coreEditor string) (string, error) {
+	candidates := []string{configured, os.Getenv("GIT_EDITOR"), coreEditor}
+	if os.Getenv("TERM") != "dumb" {
+		candidates = append(candidates, os.Getenv("VISUAL"))
+	}
+	candidates = append(candidates, os.Getenv("EDITOR"))
+	for _, c := range candidates {
+		if c = strings.TrimSpace(c); c != "" {
+			return c, nil
+		}
+	}
+
+	for _, name := range []string{"nvim", "vim", "nano", "vi"} {
+		if _, err := exec.LookPath(name); err == nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no editor found. Please set GIT_EDITOR, VISUAL or EDITOR, git's core.editor or 'editor' in the config file")
+}
+
+// command runs editorCmd on path the way git does: through the shell, so
+// editors with arguments, quotes or spaces in their path work.
+func command(editorCmd, path string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		parts := strings.Fields(editorCmd)
+		return exec.Command(parts[0], append(parts[1:], path)...) // #nosec G204 -- The editor is chosen by the user.
+	}
+	return exec.Command("sh", "-c", editorCmd+` "$@"`, editorCmd, path) // #nosec G204 -- The editor is chosen by the user.
+}
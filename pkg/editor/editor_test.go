@@ -0,0 +1,69 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEditor writes a script that appends its first argument and a marker
+// line to the edited file.
+func fakeEditor(t *testing.T, dir string) string {
+	t.Helper()
+	script := filepath.Join(dir, "fake editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '%s\\nedited\\n' \"$1\" >> \"$2\"\n"), 0o755))
+	return script
+}
+
+func TestOpen_RunsEditorThroughShell(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor commands run through sh")
+	}
+
+	script := fakeEditor(t, t.TempDir())
+	got, err := Open("message\n", `"`+script+`" '--wait here'`)
+	require.NoError(t, err)
+	assert.Equal(t, "message\n--wait here\nedited\n", got)
+}
+
+func TestOpen_EditorFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("editor commands run through sh")
+	}
+
+	_, err := Open("message", "false")
+	assert.ErrorContains(t, err, "editor command failed")
+}
+
+func TestResolve(t *testing.T) {
+	for _, key := range []string{"GIT_EDITOR", "VISUAL", "EDITOR", "TERM"} {
+		t.Setenv(key, "")
+	}
+
+	t.Setenv("EDITOR", "ed")
+	got, err := Resolve("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "ed", got)
+
+	t.Setenv("VISUAL", "vis")
+	got, _ = Resolve("", "")
+	assert.Equal(t, "vis", got)
+
+	t.Setenv("TERM", "dumb")
+	got, _ = Resolve("", "")
+	assert.Equal(t, "ed", got, "VISUAL is ignored on dumb terminals")
+
+	got, _ = Resolve("", "code --wait")
+	assert.Equal(t, "code --wait", got)
+
+	t.Setenv("GIT_EDITOR", "git-ed")
+	got, _ = Resolve("", "code --wait")
+	assert.Equal(t, "git-ed", got)
+
+	got, _ = Resolve("  nano  ", "code --wait")
+	assert.Equal(t, "nano", got)
+}
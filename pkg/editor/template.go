@@ -0,0 +1,120 @@
+package editor
+
+import (
+	"strings"
+)
+
+// scissors marks the start of the part of the buffer that is ignored, like
+// the line git writes above the diff for `commit --verbose`.
+const scissors = "------------------------ >8 ------------------------"
+
+// autoCommentChars are tried in order for core.commentChar=auto, as in git.
+const autoCommentChars = "#;@!$%^&|:"
+
+// Template is a git-style editor buffer: the message, commented sections
+// and, optionally, a diff below a scissors line.
+type Template struct {
+	Message     string
+	CommentChar string
+	// Sections are printed as comment blocks separated by an empty comment line.
+	Sections [][]string
+	// Diff is shown below the scissors line and ignored on read-back.
+	Diff string
+}
+
+// ResolveCommentChar returns the comment character for setting (git's
+// core.commentChar). "auto" picks a character no line of message starts with.
+func ResolveCommentChar(setting, message string) string {
+	setting = strings.TrimSpace(setting)
+	if setting == "" {
+		return "#"
+	}
+	if setting != "auto" {
+		return setting
+	}
+	for _, c := range autoCommentChars {
+		used := false
+		for _, line := range strings.Split(message, "\n") {
+			if strings.HasPrefix(line, string(c)) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return string(c)
+		}
+	}
+	return "#"
+}
+
+// Render returns the buffer written to the editor.
+func (t Template) Render() string {
+	c := t.commentChar()
+
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(t.Message, "\n"))
+	b.WriteString("\n\n")
+
+	first := true
+	for _, section := range t.Sections {
+		if len(section) == 0 {
+			continue
+		}
+		if !first {
+			b.WriteString(c + "\n")
+		}
+		first = false
+		for _, line := range section {
+			writeComment(&b, c, line)
+		}
+	}
+
+	if t.Diff != "" {
+		if !first {
+			b.WriteString(c + "\n")
+		}
+		writeComment(&b, c, scissors)
+		writeComment(&b, c, "Do not modify or remove the line above.")
+		writeComment(&b, c, "Everything below it will be ignored.")
+		b.WriteString(strings.TrimRight(t.Diff, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Cleanup removes everything from the scissors line on and all comment lines,
+// then trims surrounding blank lines, like git's "scissors" cleanup mode
+// combined with "strip".
+func Cleanup(content, commentChar string) string {
+	if commentChar == "" {
+		commentChar = "#"
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	var kept []string
+	for _, line := range strings.Split(content, "\n") {
+		if line == commentChar+" "+scissors {
+			break
+		}
+		if strings.HasPrefix(line, commentChar) {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+func (t Template) commentChar() string {
+	if t.CommentChar == "" {
+		return "#"
+	}
+	return t.CommentChar
+}
+
+func writeComment(b *strings.Builder, c, line string) {
+	if line == "" {
+		b.WriteString(c + "\n")
+		return
+	}
+	b.WriteString(c + " " + line + "\n")
+}
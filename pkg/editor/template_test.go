@@ -0,0 +1,50 @@
+package editor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate_RenderAndCleanup(t *testing.T) {
+	tpl := Template{
+		Message:     "feat(cli): add flag\n\nExplain why.",
+		CommentChar: ";",
+		Sections: [][]string{
+			{"Please enter the commit message.", ""},
+			{},
+			{"Changes to be committed:", "\tmodified:   main.go"},
+		},
+		Diff: "diff --git a/main.go b/main.go\n+# not a comment\n",
+	}
+
+	got := tpl.Render()
+	assert.Equal(t, `feat(cli): add flag
+
+Explain why.
+
+; Please enter the commit message.
+;
+;
+; Changes to be committed:
+; 	modified:   main.go
+;
+; ------------------------ >8 ------------------------
+; Do not modify or remove the line above.
+; Everything below it will be ignored.
+diff --git a/main.go b/main.go
++# not a comment
+`, got)
+
+	assert.Equal(t, "feat(cli): add flag\n\nExplain why.", Cleanup(got, ";"))
+	assert.Equal(t, "# kept\nbody", Cleanup("# kept\n; dropped\nbody  \n\n", ";"))
+	assert.Equal(t, "", Cleanup("# only comments\n", "#"))
+}
+
+func TestResolveCommentChar(t *testing.T) {
+	assert.Equal(t, "#", ResolveCommentChar("", "msg"))
+	assert.Equal(t, ";", ResolveCommentChar(";", "msg"))
+	assert.Equal(t, "#", ResolveCommentChar("auto", "fix: x\n\nbody"))
+	assert.Equal(t, ";", ResolveCommentChar("auto", "fix: x\n\n#123 is fixed"))
+	assert.Equal(t, "@", ResolveCommentChar("auto", "#a\n;b"))
+}
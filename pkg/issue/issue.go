@@ -0,0 +1,53 @@
+// Package issue extracts issue keys such as PROJ-1234 from branch names.
+package issue
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultPattern matches Jira-style keys anywhere in the branch name.
+const DefaultPattern = `[A-Z][A-Z0-9]+-[0-9]+`
+
+// Matcher extracts issue keys with a list of regular expressions. When a
+// pattern has a capture group, the first group is the key; otherwise the
+// whole match is.
+type Matcher struct {
+	patterns []*regexp.Regexp
+}
+
+// NewMatcher compiles patterns, using DefaultPattern when there are none.
+func NewMatcher(patterns []string) (*Matcher, error) {
+	if len(patterns) == 0 {
+		patterns = []string{DefaultPattern}
+	}
+
+	m := &Matcher{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue pattern %q: %w", p, err)
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	return m, nil
+}
+
+// Keys returns the unique issue keys in branch, in order of appearance.
+func (m *Matcher) Keys(branch string) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, re := range m.patterns {
+		for _, match := range re.FindAllStringSubmatch(branch, -1) {
+			key := match[0]
+			if len(match) > 1 && match[1] != "" {
+				key = match[1]
+			}
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,25 @@
+package issue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_Keys(t *testing.T) {
+	m, err := NewMatcher(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROJ-1234"}, m.Keys("feature/PROJ-1234-short-desc"))
+	assert.Equal(t, []string{"AB-1", "CD-22"}, m.Keys("fix/AB-1-and-CD-22-AB-1"))
+	assert.Empty(t, m.Keys("main"))
+
+	m, err = NewMatcher([]string{`^(?:feature|fix)/(\d+)-`, `#(\d+)`})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"42"}, m.Keys("feature/42-login"))
+	assert.Equal(t, []string{"7"}, m.Keys("hotfix/#7"))
+	assert.Empty(t, m.Keys("feature/PROJ-1-x"))
+
+	_, err = NewMatcher([]string{"("})
+	assert.Error(t, err)
+}
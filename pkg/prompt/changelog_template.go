@@ -0,0 +1,38 @@
+package prompt
+
+import "fmt"
+
+// ChangelogTemplate generates prompts that polish the wording of a generated
+// Keep a Changelog section without changing its structure.
+type ChangelogTemplate struct {
+	systemPrompt string
+	userPrompt   string
+}
+
+func NewChangelogTemplate() *ChangelogTemplate {
+	return &ChangelogTemplate{
+		systemPrompt: `You are a technical writer maintaining a CHANGELOG.md in the Keep a Changelog format. You improve wording for end users without changing facts.`,
+		userPrompt: `Polish the wording of this CHANGELOG.md section.
+
+<section>
+%s
+</section>
+
+RULES:
+1. Output ONLY the Markdown section (no code fences, no commentary).
+2. Keep the first "## [...]" heading line exactly as it is.
+3. Keep the "### ..." section headings and their order; do not add new sections.
+4. Keep one bullet per original bullet; do not merge, drop or invent entries.
+5. Rewrite bullets as short, user-facing sentences starting with a capital letter.
+6. Keep bold scopes such as "**auth:**" at the start of a bullet.
+`,
+	}
+}
+
+func (t *ChangelogTemplate) GeneratePrompt(input string) string {
+	return fmt.Sprintf(t.userPrompt, input)
+}
+
+func (t *ChangelogTemplate) GetSystemPrompt() string {
+	return t.systemPrompt
+}
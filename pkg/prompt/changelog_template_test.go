@@ -0,0 +1,17 @@
+package prompt
+
+import "testing"
+
+func TestChangelogTemplate_GeneratePrompt(t *testing.T) {
+	tpl := NewChangelogTemplate()
+	p := tpl.GeneratePrompt("## [1.2.0] - 2024-01-01\n\n### Added\n- **cli:** add flag\n")
+
+	if !containsAll(p,
+		"<section>",
+		"## [1.2.0] - 2024-01-01",
+		"Keep the first \"## [...]\" heading line exactly",
+		"do not merge, drop or invent entries",
+	) {
+		t.Fatalf("prompt missing expected content:\n%s", p)
+	}
+}
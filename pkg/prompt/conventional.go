@@ -0,0 +1,134 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Conventional Commits v1.0.0 summary format:
+// <type>[optional scope][optional !]: <description>
+//
+// Notes:
+// - The spec says types are not case sensitive for implementors.
+// - We allow a conservative subset for type/scope characters to prevent malformed subjects.
+var conventionalSubjectPattern = regexp.MustCompile(`(?i)^([a-z][a-z0-9-]*)(\(([^\s)]+)\))?(!)?: (.+)`)
+
+// footerPattern matches a git trailer ("Token: value") or a Conventional
+// Commits "Token #value" footer. BREAKING CHANGE is the only token that may
+// contain a space.
+var footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z0-9-]*)(: | #)(.*)$`)
+
+// Footer is a single trailer line of a commit message, e.g. "Refs: #123".
+type Footer struct {
+	Token string `json:"token"`
+	Value string `json:"value"`
+}
+
+// ConventionalCommit is a commit message split into its Conventional Commits parts.
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	Footers     []Footer
+	// Breaking is set by a "!" in the subject or a BREAKING CHANGE footer.
+	Breaking bool
+}
+
+// BreakingNote returns the BREAKING CHANGE footer text, falling back to the
+// description for commits that only use "!".
+func (c ConventionalCommit) BreakingNote() string {
+	for _, f := range c.Footers {
+		if isBreakingToken(f.Token) {
+			return f.Value
+		}
+	}
+	if c.Breaking {
+		return c.Description
+	}
+	return ""
+}
+
+// ParseConventionalCommit parses message according to Conventional Commits v1.0.0.
+// The type is returned in lower case.
+func ParseConventionalCommit(message string) (ConventionalCommit, error) {
+	message = strings.TrimSpace(normalizeNewlines(message))
+	if message == "" {
+		return ConventionalCommit{}, fmt.Errorf("commit message cannot be empty")
+	}
+
+	lines := strings.Split(message, "\n")
+	subject := strings.TrimRight(lines[0], " \t")
+	m := conventionalSubjectPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return ConventionalCommit{}, fmt.Errorf("commit subject must use Conventional Commits v1.0.0 summary format: <type>(<scope>)?!: <description>")
+	}
+
+	c := ConventionalCommit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[3],
+		Breaking:    m[4] == "!",
+		Description: strings.TrimSpace(m[5]),
+	}
+
+	body, footers := splitFooters(lines[1:])
+	c.Body = body
+	c.Footers = footers
+	for _, f := range footers {
+		if isBreakingToken(f.Token) {
+			c.Breaking = true
+		}
+	}
+
+	return c, nil
+}
+
+// splitFooters separates the trailing footer paragraph from the body.
+func splitFooters(lines []string) (string, []Footer) {
+	text := strings.TrimSpace(strings.Join(lines, "\n"))
+	if text == "" {
+		return "", nil
+	}
+
+	paragraphs := strings.Split(text, "\n\n")
+	last := strings.Split(paragraphs[len(paragraphs)-1], "\n")
+
+	var footers []Footer
+	for _, line := range last {
+		if m := footerPattern.FindStringSubmatch(line); m != nil {
+			value := m[3]
+			if m[2] == " #" {
+				value = "#" + value
+			}
+			footers = append(footers, Footer{Token: m[1], Value: strings.TrimSpace(value)})
+			continue
+		}
+		// Continuation lines of a multi-line footer value.
+		if len(footers) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			footers[len(footers)-1].Value += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		return text, nil
+	}
+
+	body := strings.TrimSpace(strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"))
+	return body, footers
+}
+
+func isBreakingToken(token string) bool {
+	return token == "BREAKING CHANGE" || token == "BREAKING-CHANGE"
+}
+
+// ReplaceScope rewrites the scope of the subject line of message, keeping the
+// type, "!" marker, description and the rest of the message unchanged.
+func ReplaceScope(message, scope string) (string, error) {
+	return FormatConventional.ReplaceScope(message, scope)
+}
+
+// PrefixDescription inserts prefix before the description of the subject
+// line, e.g. "feat(api): PROJ-1 add login". Messages whose description
+// already starts with prefix are returned unchanged.
+func PrefixDescription(message, prefix string) (string, error) {
+	return FormatConventional.PrefixDescription(message, prefix)
+}
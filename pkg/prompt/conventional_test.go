@@ -65,3 +65,89 @@ func TestValidateConventionalCommitMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestParseConventionalCommit(t *testing.T) {
+	c, err := ParseConventionalCommit("Feat(api)!: drop v1 endpoints\n\nThe v1 API was deprecated last year.\n\nRefs: #42\nBREAKING CHANGE: clients must use /v2\n  and re-authenticate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Type != "feat" || c.Scope != "api" || !c.Breaking || c.Description != "drop v1 endpoints" {
+		t.Fatalf("unexpected subject parts: %+v", c)
+	}
+	if c.Body != "The v1 API was deprecated last year." {
+		t.Fatalf("unexpected body: %q", c.Body)
+	}
+	if len(c.Footers) != 2 || c.Footers[0] != (Footer{Token: "Refs", Value: "#42"}) {
+		t.Fatalf("unexpected footers: %+v", c.Footers)
+	}
+	if got := c.BreakingNote(); got != "clients must use /v2\nand re-authenticate" {
+		t.Fatalf("unexpected breaking note: %q", got)
+	}
+
+	c, err = ParseConventionalCommit("fix: handle nil config\n\nCloses #7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Breaking || c.Body != "" || len(c.Footers) != 1 || c.Footers[0].Value != "#7" {
+		t.Fatalf("unexpected parse result: %+v", c)
+	}
+
+	c, err = ParseConventionalCommit("refactor!: rename package\n\nMoves code around.\nNot a footer line")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.Breaking || c.BreakingNote() != "rename package" || len(c.Footers) != 0 {
+		t.Fatalf("unexpected parse result: %+v", c)
+	}
+	if c.Body != "Moves code around.\nNot a footer line" {
+		t.Fatalf("unexpected body: %q", c.Body)
+	}
+
+	if _, err := ParseConventionalCommit("Update readme"); err == nil {
+		t.Fatal("expected error for non-conventional subject")
+	}
+}
+
+func TestReplaceScope(t *testing.T) {
+	tests := []struct {
+		message string
+		scope   string
+		want    string
+	}{
+		{"feat: add login", "auth", "feat(auth): add login"},
+		{"fix(core)!: drop flag\n\nBody text.", "api", "fix(api)!: drop flag\n\nBody text."},
+		{"chore(deps): bump", "", "chore: bump"},
+	}
+	for _, tt := range tests {
+		got, err := ReplaceScope(tt.message, tt.scope)
+		if err != nil {
+			t.Fatalf("ReplaceScope(%q): unexpected error: %v", tt.message, err)
+		}
+		if got != tt.want {
+			t.Fatalf("ReplaceScope(%q, %q) = %q, want %q", tt.message, tt.scope, got, tt.want)
+		}
+	}
+
+	if _, err := ReplaceScope("Update readme", "docs"); err == nil {
+		t.Fatal("expected error for non-conventional subject")
+	}
+}
+
+func TestPrefixDescription(t *testing.T) {
+	got, err := PrefixDescription("feat(api)!: add login\n\nBody.", "PROJ-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "feat(api)!: PROJ-1 add login\n\nBody." {
+		t.Fatalf("unexpected message: %q", got)
+	}
+
+	again, err := PrefixDescription(got, "PROJ-1")
+	if err != nil || again != got {
+		t.Fatalf("prefix should not be added twice: %q, %v", again, err)
+	}
+
+	if _, err := PrefixDescription("Update readme", "PROJ-1"); err == nil {
+		t.Fatal("expected error for non-conventional subject")
+	}
+}
@@ -0,0 +1,51 @@
+package prompt
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxExampleLen skips very long messages (e.g. release merges) as examples.
+const maxExampleLen = 1200
+
+// SelectExamples picks up to count distinct, well-formed messages in format,
+// taking preferred (e.g. commits touching the same paths) before fallback.
+// Messages are expected newest first.
+func SelectExamples(preferred, fallback []string, count int, format Format) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, list := range [][]string{preferred, fallback} {
+		for _, m := range list {
+			if len(out) == count {
+				return out
+			}
+			m = strings.TrimSpace(normalizeNewlines(m))
+			if seen[m] || len(m) > maxExampleLen {
+				continue
+			}
+			seen[m] = true
+			if ValidateCommitMessage(m) != nil {
+				continue
+			}
+			if subject, _, _ := strings.Cut(m, "\n"); !isExampleSubject(subject, format) {
+				continue
+			}
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func isExampleSubject(subject string, format Format) bool {
+	if format.Validate(subject) != nil {
+		return false
+	}
+	if format == FormatFreeForm {
+		// Skip typed subjects and merge or revert messages generated by git.
+		_, err := FormatConventional.ParseHeader(subject)
+		first, _ := utf8.DecodeRuneInString(subject)
+		return err != nil && unicode.IsUpper(first) && !strings.HasPrefix(subject, "Merge ") && !strings.HasPrefix(subject, "Revert \"")
+	}
+	return true
+}
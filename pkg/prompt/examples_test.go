@@ -0,0 +1,56 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectExamples(t *testing.T) {
+	preferred := []string{
+		"feat(api): add login",
+		"WIP",
+		"fix(api): handle timeouts\n\nRequests no longer hang.",
+	}
+	fallback := []string{
+		"feat(api): add login",
+		"Merge branch 'main'",
+		"docs: update readme",
+		"chore: " + strings.Repeat("x", maxExampleLen),
+		"ci: cache modules",
+	}
+
+	assert.Equal(t, []string{
+		"feat(api): add login",
+		"fix(api): handle timeouts\n\nRequests no longer hang.",
+		"docs: update readme",
+	}, SelectExamples(preferred, fallback, 3, FormatConventional))
+
+	assert.Equal(t, []string{"docs: update readme", "ci: cache modules"}, SelectExamples(nil, fallback[2:], 5, FormatConventional))
+	assert.Empty(t, SelectExamples(preferred, fallback, 0, FormatConventional))
+
+	history := []string{
+		"✨ Add login",
+		"feat: add logout",
+		"Merge branch 'main'",
+		"Revert \"Add login\"",
+		"Fix typo in readme",
+		"wip",
+	}
+	assert.Equal(t, []string{"✨ Add login"}, SelectExamples(history, nil, 5, FormatGitmoji))
+	assert.Equal(t, []string{"Fix typo in readme"}, SelectExamples(history, nil, 5, FormatFreeForm))
+}
+
+func TestDefaultTemplateExamples(t *testing.T) {
+	tpl := NewDefaultTemplate()
+	tpl.SetExamples([]string{"feat(api): add login", "fix: handle nil\n\nBody."})
+
+	p := tpl.GeneratePrompt("diff")
+	if !containsAll(p,
+		"EXAMPLES FROM THIS REPOSITORY",
+		"---\nfeat(api): add login\n---\nfix: handle nil\n\nBody.\n---",
+	) {
+		t.Fatalf("prompt missing repository examples:\n%s", p)
+	}
+}
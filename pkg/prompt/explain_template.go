@@ -0,0 +1,53 @@
+package prompt
+
+import "fmt"
+
+// ExplainTemplate generates prompts that explain existing commits in plain
+// language.
+type ExplainTemplate struct {
+	markdown bool
+	language Language
+}
+
+// NewExplainTemplate creates an explain template that asks for Markdown or,
+// if markdown is false, plain text.
+func NewExplainTemplate(markdown bool) *ExplainTemplate {
+	return &ExplainTemplate{markdown: markdown}
+}
+
+// SetLanguage selects the language of the explanation.
+func (t *ExplainTemplate) SetLanguage(lang Language) {
+	t.language = lang
+}
+
+func (t *ExplainTemplate) GeneratePrompt(input string) string {
+	output := `Output plain text only: no Markdown headings, emphasis, tables or code fences. Use "- " for lists.`
+	if t.markdown {
+		output = "Use GitHub-flavoured Markdown: short paragraphs, bullet lists and `code` for identifiers. Do not wrap the whole output in code fences."
+	}
+
+	p := fmt.Sprintf(`Explain the Git history below to a colleague who has not seen it.
+
+<history>
+%s
+</history>
+
+RULES:
+1. Start with one or two sentences summarising what changed overall.
+2. Then explain the notable changes and why they were made, as far as the commit messages and code show it.
+   - Group related changes; do not walk through the diff file by file.
+   - If the reason for a change is not evident, say so instead of guessing.
+3. Point out behaviour changes, compatibility or migration concerns and risks.
+4. Base the explanation ONLY on the provided history. Do not invent changes.
+5. %s
+`, input, output)
+
+	if !t.language.IsEnglish() {
+		p = appendSection(p, fmt.Sprintf("LANGUAGE:\nWrite the explanation in %s. Keep identifiers, file names and commit subjects as they are.\n", t.language.Name))
+	}
+	return p
+}
+
+func (t *ExplainTemplate) GetSystemPrompt() string {
+	return `You are a senior software engineer who explains changes in a codebase to colleagues. You read commit messages and diffs carefully and describe what changed and why in plain language, based strictly on the provided history.`
+}
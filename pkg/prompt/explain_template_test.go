@@ -0,0 +1,24 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainTemplate_GeneratePrompt(t *testing.T) {
+	markdown := NewExplainTemplate(true)
+	p := markdown.GeneratePrompt("commit abc123\n\n    fix: handle empty diff\n")
+	assert.Contains(t, p, "<history>\ncommit abc123")
+	assert.Contains(t, p, "GitHub-flavoured Markdown")
+	assert.NotContains(t, p, "LANGUAGE:")
+	assert.NotContains(t, p, "%!")
+
+	plain := NewExplainTemplate(false)
+	plain.SetLanguage(languages["de"])
+	p = plain.GeneratePrompt("100% of the history")
+	assert.Contains(t, p, "100% of the history")
+	assert.Contains(t, p, "Output plain text only")
+	assert.Contains(t, p, "LANGUAGE:\nWrite the explanation in German.")
+	assert.NotEmpty(t, plain.GetSystemPrompt())
+}
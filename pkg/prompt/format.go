@@ -0,0 +1,326 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Format is a commit message convention. It selects the prompt instructions,
+// the subject checks of LintCommitMessage and the fixes of FixCommitMessage.
+// The empty Format is FormatConventional.
+type Format string
+
+const (
+	FormatConventional        Format = "conventional"
+	FormatAngular             Format = "angular"
+	FormatGitmoji             Format = "gitmoji"
+	FormatConventionalGitmoji Format = "conventional+gitmoji"
+	FormatFreeForm            Format = "free-form"
+)
+
+var formats = []Format{FormatConventional, FormatAngular, FormatGitmoji, FormatConventionalGitmoji, FormatFreeForm}
+
+// AngularTypes are the commit types allowed by the Angular convention.
+var AngularTypes = []string{"build", "ci", "docs", "feat", "fix", "perf", "refactor", "test"}
+
+// gitmojiForType maps Conventional Commit types to the matching gitmoji.
+var gitmojiForType = map[string]string{
+	"feat":     "✨",
+	"fix":      "🐛",
+	"docs":     "📝",
+	"style":    "🎨",
+	"refactor": "♻️",
+	"perf":     "⚡️",
+	"test":     "✅",
+	"build":    "📦️",
+	"ci":       "👷",
+	"chore":    "🔧",
+	"revert":   "⏪️",
+	"security": "🔒️",
+	"deps":     "⬆️",
+}
+
+const breakingGitmoji = "💥"
+
+var (
+	gitmojiCodePattern  = regexp.MustCompile(`^:[a-z0-9_+-]+:`)
+	gitmojiScopePattern = regexp.MustCompile(`^\(([^\s)]+)\):?\s+(.+)$`)
+)
+
+// ParseFormat returns the format named by value; an empty value selects
+// Conventional Commits.
+func ParseFormat(value string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(value)))
+	if f == "" {
+		return FormatConventional, nil
+	}
+	for _, known := range formats {
+		if f == known {
+			return f, nil
+		}
+	}
+	names := make([]string, len(formats))
+	for i, known := range formats {
+		names[i] = string(known)
+	}
+	return "", fmt.Errorf("unsupported commit format %q (supported: %s)", value, strings.Join(names, ", "))
+}
+
+// Header is the parsed subject line of a commit message. Fields the format
+// does not use are empty.
+type Header struct {
+	// Type is returned as written; see LintCommitMessage for the case check.
+	Type  string
+	Scope string
+	// Breaking is set by "!" or, for gitmoji, by 💥.
+	Breaking    bool
+	Gitmoji     string
+	Description string
+}
+
+func (f Format) typed() bool {
+	return f == "" || f == FormatConventional || f == FormatAngular || f == FormatConventionalGitmoji
+}
+
+// HasScope reports whether subjects in format f can name a scope.
+func (f Format) HasScope() bool {
+	return f != FormatFreeForm
+}
+
+// Syntax describes the subject line, e.g. "<type>(<scope>)?!: <description>".
+func (f Format) Syntax() string {
+	switch f {
+	case FormatAngular:
+		return "<type>(<scope>): <short summary>"
+	case FormatGitmoji:
+		return "<gitmoji> (<scope>)?: <Description>"
+	case FormatConventionalGitmoji:
+		return "<type>(<scope>)?!: <gitmoji> <description>"
+	case FormatFreeForm:
+		return "<Capitalised imperative summary>"
+	}
+	return "<type>(<scope>)?!: <description>"
+}
+
+func (f Format) title() string {
+	switch f {
+	case FormatAngular:
+		return "Angular commit"
+	case FormatGitmoji:
+		return "gitmoji"
+	case FormatConventionalGitmoji:
+		return "Conventional Commits v1.0.0 with gitmoji"
+	case FormatFreeForm:
+		return "free-form"
+	}
+	return "Conventional Commits v1.0.0"
+}
+
+func (f Format) headerError() error {
+	return fmt.Errorf("commit subject must use %s summary format: %s", f.title(), f.Syntax())
+}
+
+// ParseHeader parses the subject line of a commit message in format f.
+func (f Format) ParseHeader(subject string) (Header, error) {
+	subject = strings.TrimSpace(subject)
+	switch f {
+	case FormatFreeForm:
+		if subject == "" {
+			return Header{}, fmt.Errorf("commit subject cannot be empty")
+		}
+		return Header{Description: subject}, nil
+	case FormatGitmoji:
+		emoji, rest, ok := cutGitmoji(subject)
+		if !ok {
+			return Header{}, f.headerError()
+		}
+		h := Header{Gitmoji: emoji, Breaking: isBreakingGitmoji(emoji), Description: rest}
+		if m := gitmojiScopePattern.FindStringSubmatch(rest); m != nil {
+			h.Scope, h.Description = m[1], m[2]
+		}
+		return h, nil
+	}
+
+	m := conventionalSubjectPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return Header{}, f.headerError()
+	}
+	h := Header{Type: m[1], Scope: m[3], Breaking: m[4] == "!", Description: strings.TrimSpace(m[5])}
+	if f == FormatConventionalGitmoji {
+		emoji, rest, ok := cutGitmoji(h.Description)
+		if !ok {
+			return Header{}, f.headerError()
+		}
+		h.Gitmoji, h.Description = emoji, rest
+	}
+	return h, nil
+}
+
+// Validate checks that message has a subject line in format f.
+func (f Format) Validate(message string) error {
+	message = strings.TrimSpace(normalizeNewlines(message))
+	if message == "" {
+		return fmt.Errorf("commit message cannot be empty")
+	}
+	subject, _, _ := strings.Cut(message, "\n")
+	if subject = strings.TrimRight(subject, " \t"); subject == "" {
+		return fmt.Errorf("commit subject cannot be empty")
+	}
+	_, err := f.ParseHeader(subject)
+	return err
+}
+
+// Subject formats h as a subject line in format f.
+func (f Format) Subject(h Header) string {
+	switch f {
+	case FormatFreeForm:
+		return h.Description
+	case FormatGitmoji:
+		s := h.Gitmoji + " "
+		if h.Scope != "" {
+			s += "(" + h.Scope + "): "
+		}
+		return s + h.Description
+	}
+
+	s := h.Type
+	if h.Scope != "" {
+		s += "(" + h.Scope + ")"
+	}
+	if h.Breaking {
+		s += "!"
+	}
+	s += ": "
+	if f == FormatConventionalGitmoji && h.Gitmoji != "" {
+		s += h.Gitmoji + " "
+	}
+	return s + h.Description
+}
+
+// ReplaceScope rewrites the scope of the subject line of message, keeping
+// the rest of the message unchanged.
+func (f Format) ReplaceScope(message, scope string) (string, error) {
+	if !f.HasScope() {
+		return "", fmt.Errorf("%s commit messages have no scope", f.title())
+	}
+	return f.editSubject(message, func(h *Header) { h.Scope = scope })
+}
+
+// PrefixDescription inserts prefix before the description of the subject
+// line, e.g. "feat(api): PROJ-1 add login". Messages whose description
+// already starts with prefix are returned unchanged.
+func (f Format) PrefixDescription(message, prefix string) (string, error) {
+	return f.editSubject(message, func(h *Header) {
+		if prefix != "" && !strings.HasPrefix(h.Description, prefix) {
+			h.Description = prefix + " " + h.Description
+		}
+	})
+}
+
+func (f Format) editSubject(message string, edit func(h *Header)) (string, error) {
+	message = normalizeNewlines(message)
+	subject, rest, hasRest := strings.Cut(message, "\n")
+	h, err := f.ParseHeader(subject)
+	if err != nil {
+		return "", err
+	}
+
+	edit(&h)
+	subject = f.Subject(h)
+	if !hasRest {
+		return subject, nil
+	}
+	return subject + "\n" + rest, nil
+}
+
+// convertHeader turns a Conventional Commits subject with a known type, which
+// models tend to fall back to, into a gitmoji header of format f.
+func (f Format) convertHeader(subject string) (Header, bool) {
+	h, err := FormatConventional.ParseHeader(subject)
+	if err != nil {
+		return Header{}, false
+	}
+	switch f {
+	case FormatGitmoji, FormatConventionalGitmoji:
+		if h.Gitmoji = gitmojiFor(h); h.Gitmoji == "" {
+			return Header{}, false
+		}
+		if f == FormatGitmoji {
+			h.Type, h.Breaking = "", h.Gitmoji == breakingGitmoji
+		}
+		return h, true
+	}
+	return Header{}, false
+}
+
+func gitmojiFor(h Header) string {
+	if h.Breaking {
+		return breakingGitmoji
+	}
+	return gitmojiForType[strings.ToLower(h.Type)]
+}
+
+// gitmojiCodeForType maps Conventional Commit types to the shortcodes of
+// gitmojiForType.
+var gitmojiCodeForType = map[string]string{
+	"feat":     ":sparkles:",
+	"fix":      ":bug:",
+	"docs":     ":memo:",
+	"style":    ":art:",
+	"refactor": ":recycle:",
+	"perf":     ":zap:",
+	"test":     ":white_check_mark:",
+	"build":    ":package:",
+	"ci":       ":construction_worker:",
+	"chore":    ":wrench:",
+	"revert":   ":rewind:",
+	"security": ":lock:",
+	"deps":     ":arrow_up:",
+}
+
+// GitmojiType returns the Conventional Commit type a gitmoji stands for,
+// written as an emoji or as a ":shortcode:", or "" when there is none.
+func GitmojiType(emoji string) string {
+	emoji = strings.ReplaceAll(emoji, "\uFE0F", "")
+	for typ, known := range gitmojiForType {
+		if emoji == strings.ReplaceAll(known, "\uFE0F", "") || emoji == gitmojiCodeForType[typ] {
+			return typ
+		}
+	}
+	return ""
+}
+
+// cutGitmoji splits a leading gitmoji, written as an emoji or as a
+// ":shortcode:", from the rest of s.
+func cutGitmoji(s string) (emoji, rest string, ok bool) {
+	emoji = gitmojiCodePattern.FindString(s)
+	if emoji == "" {
+		end := 0
+		for end < len(s) {
+			r, size := utf8.DecodeRuneInString(s[end:])
+			if !unicode.Is(unicode.So, r) && (end == 0 || !isEmojiModifier(r)) {
+				break
+			}
+			end += size
+		}
+		emoji = s[:end]
+	}
+	if emoji == "" || !strings.HasPrefix(s[len(emoji):], " ") {
+		return "", s, false
+	}
+	rest = strings.TrimSpace(s[len(emoji):])
+	return emoji, rest, rest != ""
+}
+
+// isEmojiModifier reports whether r continues an emoji sequence: variation
+// selectors, zero-width joiners and skin tones.
+func isEmojiModifier(r rune) bool {
+	return r == '\uFE0F' || r == '\u200D' || (r >= 0x1F3FB && r <= 0x1F3FF)
+}
+
+func isBreakingGitmoji(emoji string) bool {
+	return emoji == breakingGitmoji || emoji == ":boom:"
+}
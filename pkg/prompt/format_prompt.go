@@ -0,0 +1,137 @@
+package prompt
+
+import "strings"
+
+// summaryLine completes the system prompt sentence "... and use <summaryLine>".
+func (f Format) summaryLine() string {
+	switch f {
+	case FormatAngular:
+		return "an Angular-style summary line"
+	case FormatGitmoji:
+		return "a gitmoji summary line"
+	case FormatConventionalGitmoji:
+		return "a Conventional Commits v1.0.0-style summary line with a gitmoji"
+	case FormatFreeForm:
+		return "a capitalised, imperative summary line"
+	}
+	return "a Conventional Commits v1.0.0-style summary line"
+}
+
+// breakingRule tells the model how to mark a breaking change in the subject.
+func (f Format) breakingRule() string {
+	switch f {
+	case FormatGitmoji:
+		return "Use " + breakingGitmoji + " only if the combined change breaks compatibility"
+	case FormatFreeForm:
+		return "Describe breaking changes in a BREAKING CHANGE footer"
+	}
+	return `Use "!" only if the combined change breaks compatibility`
+}
+
+// subjectRules are the "Subject" rules of the commit prompt.
+func (f Format) subjectRules(lang Language) string {
+	var rules []string
+	switch f {
+	case FormatAngular:
+		rules = []string{
+			"MUST use the Angular commit format: " + f.Syntax(),
+			"type MUST be one of: " + strings.Join(AngularTypes, ", "),
+			"scope is optional and names the affected package or area (e.g. core, router)",
+			"Summary in present tense, starting with a lower-case letter",
+		}
+	case FormatGitmoji:
+		rules = []string{
+			"MUST use the gitmoji format: " + f.Syntax(),
+			"Start with exactly one gitmoji (https://gitmoji.dev) that matches the intention, as an emoji: " + gitmojiLegend,
+			"scope is optional (e.g. ✨ (auth): Add login)",
+			"Capitalise the first word",
+		}
+	case FormatConventionalGitmoji:
+		rules = []string{
+			"MUST use Conventional Commits v1.0.0 summary format with a gitmoji: " + f.Syntax(),
+			"type MUST be a single lowercase word (do not invent new punctuation in type)",
+			"The gitmoji follows the colon and matches the type: " + gitmojiLegend,
+			"scope is optional and should be short (e.g. auth, cli, git)",
+			`"!" is optional and indicates a breaking change`,
+		}
+	case FormatFreeForm:
+		rules = []string{
+			"A plain summary, e.g. \"Add dry-run flag\"; no type prefix, scope or emoji",
+			"Capitalise the first word",
+		}
+	default:
+		rules = []string{
+			"MUST use Conventional Commits v1.0.0 summary format: " + f.Syntax(),
+			"type MUST be a single lowercase word (do not invent new punctuation in type)",
+			"scope is optional and should be short (e.g. auth, cli, git)",
+			`"!" is optional and indicates a breaking change`,
+			"Use an optional scope when it helps (e.g. feat(auth): ...)",
+		}
+	}
+	rules = append(rules, lang.subjectRule(f.typed()), "No trailing period", "Prefer a concise, readable subject")
+	return "   - " + strings.Join(rules, "\n   - ")
+}
+
+const gitmojiLegend = "✨ feature, 🐛 fix, 📝 docs, 🎨 style, ♻️ refactor, ⚡️ performance, ✅ tests, 📦️ build, 👷 CI, 🔧 config/chore, ⏪️ revert, 💥 breaking change"
+
+// examples are the example messages of the commit prompt.
+func (f Format) examples() string {
+	switch f {
+	case FormatAngular:
+		return `✓ feat(router): add support for lazy-loaded routes
+✓ fix(http): avoid panic when the response body is empty
+✓ refactor(config): support XDG_CONFIG_HOME
+✓ build(deps): update dependencies to address security advisory
+✓ perf(compiler): cache parsed templates
+
+✓ feat(forms): add async validators
+
+Async validators let forms check values against the server without
+blocking user input.
+
+BREAKING CHANGE: Validators now receive the control instead of its value.`
+	case FormatGitmoji:
+		return `✓ ✨ Add dry-run flag to preview generated commit message
+✓ 🐛 (git): Avoid panic when staged diff is empty
+✓ ♻️ (config): Support XDG_CONFIG_HOME
+✓ ⬆️ Update dependencies to address security advisory
+✓ 💥 (api): Remove deprecated endpoint
+
+✓ ✨ (editor): Add editor support for reviewing commit message
+
+This lets users edit the generated message before committing and reduces
+incorrect commits caused by prompt misunderstandings.`
+	case FormatConventionalGitmoji:
+		return `✓ feat(cli): ✨ add dry-run flag to preview generated commit message
+✓ fix(git): 🐛 avoid panic when staged diff is empty
+✓ refactor(config): ♻️ support XDG_CONFIG_HOME
+✓ chore(deps): 🔧 update dependencies to address security advisory
+✓ feat(api)!: 💥 remove deprecated endpoint
+
+✓ feat(editor): ✨ add editor support for reviewing commit message
+
+This lets users edit the generated message before committing and reduces
+incorrect commits caused by prompt misunderstandings.`
+	case FormatFreeForm:
+		return `✓ Add dry-run flag to preview generated commit message
+✓ Avoid panic when staged diff is empty
+✓ Support XDG_CONFIG_HOME
+✓ Update dependencies to address security advisory
+✓ Remove deprecated endpoint
+
+✓ Add editor support for reviewing commit message
+
+This lets users edit the generated message before committing and reduces
+incorrect commits caused by prompt misunderstandings.`
+	}
+	return `✓ feat(cli): add dry-run flag to preview generated commit message
+✓ fix(git): avoid panic when staged diff is empty
+✓ refactor(config): support XDG_CONFIG_HOME
+✓ chore(deps): update dependencies to address security advisory
+✓ feat(api)!: remove deprecated endpoint
+
+✓ feat(editor): add editor support for reviewing commit message
+
+This lets users edit the generated message before committing and reduces
+incorrect commits caused by prompt misunderstandings.`
+}
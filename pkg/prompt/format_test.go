@@ -0,0 +1,139 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatConventional, f)
+
+	f, err = ParseFormat(" Conventional+Gitmoji ")
+	require.NoError(t, err)
+	assert.Equal(t, FormatConventionalGitmoji, f)
+
+	_, err = ParseFormat("emoji")
+	assert.EqualError(t, err, `unsupported commit format "emoji" (supported: conventional, angular, gitmoji, conventional+gitmoji, free-form)`)
+}
+
+func TestFormat_ParseHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		subject string
+		want    Header
+		wantErr bool
+	}{
+		{"conventional", FormatConventional, "feat(api)!: drop v1", Header{Type: "feat", Scope: "api", Breaking: true, Description: "drop v1"}, false},
+		{"conventional rejects gitmoji", FormatConventional, "✨ Add login", Header{}, true},
+		{"gitmoji", FormatGitmoji, "✨ Add login", Header{Gitmoji: "✨", Description: "Add login"}, false},
+		{"gitmoji with scope", FormatGitmoji, "♻️ (config): Support XDG", Header{Gitmoji: "♻️", Scope: "config", Description: "Support XDG"}, false},
+		{"gitmoji shortcode breaking", FormatGitmoji, ":boom: Remove v1", Header{Gitmoji: ":boom:", Breaking: true, Description: "Remove v1"}, false},
+		{"gitmoji needs space", FormatGitmoji, "✨Add login", Header{}, true},
+		{"gitmoji rejects conventional", FormatGitmoji, "feat: add login", Header{}, true},
+		{"conventional gitmoji", FormatConventionalGitmoji, "fix(git): 🐛 avoid panic", Header{Type: "fix", Scope: "git", Gitmoji: "🐛", Description: "avoid panic"}, false},
+		{"conventional gitmoji needs emoji", FormatConventionalGitmoji, "fix(git): avoid panic", Header{}, true},
+		{"free-form", FormatFreeForm, "Add login", Header{Description: "Add login"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.format.ParseHeader(tt.subject)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.subject, tt.format.Subject(got))
+		})
+	}
+}
+
+func TestFormat_ReplaceScopeAndPrefix(t *testing.T) {
+	msg, err := FormatGitmoji.ReplaceScope("✨ Add login\n\nBody.", "auth")
+	require.NoError(t, err)
+	assert.Equal(t, "✨ (auth): Add login\n\nBody.", msg)
+
+	msg, err = FormatConventionalGitmoji.PrefixDescription("feat: ✨ add login", "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "feat: ✨ PROJ-1 add login", msg)
+
+	msg, err = FormatFreeForm.PrefixDescription("Add login", "PROJ-1")
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ-1 Add login", msg)
+
+	_, err = FormatFreeForm.ReplaceScope("Add login", "auth")
+	assert.Error(t, err)
+}
+
+func TestLintCommitMessage_Formats(t *testing.T) {
+	gitmoji := DefaultRules().WithFormat(FormatGitmoji)
+	assert.Empty(t, LintCommitMessage("✨ (auth): Add login", gitmoji))
+	assert.Equal(t, []string{RuleHeaderFormat}, ruleNames(LintCommitMessage("feat: add login", gitmoji)))
+	assert.Equal(t, []string{RuleSubjectCase}, ruleNames(LintCommitMessage("✨ add login", gitmoji)))
+	v := LintCommitMessage("💥 Remove v1", gitmoji)
+	assert.Equal(t, []string{RuleBreakingConsistency}, ruleNames(v))
+	assert.Equal(t, "breaking change marked with 💥 needs a BREAKING CHANGE footer", v[0].Message)
+
+	angular := DefaultRules().WithFormat(FormatAngular)
+	assert.Empty(t, LintCommitMessage("perf(compiler): cache parsed templates", angular))
+	assert.Equal(t, []string{RuleTypeEnum}, ruleNames(LintCommitMessage("chore: update deps", angular)))
+
+	freeForm := DefaultRules().WithFormat(FormatFreeForm)
+	freeForm.ScopeRequired = true
+	assert.Empty(t, LintCommitMessage("Add login\n\nBREAKING CHANGE: sessions are reset", freeForm))
+	assert.Equal(t, []string{RuleSubjectCase, RuleSubjectFullStop}, ruleNames(LintCommitMessage("add login.", freeForm)))
+
+	combined := DefaultRules().WithFormat(FormatConventionalGitmoji)
+	assert.Empty(t, LintCommitMessage("feat(api)!: 💥 drop v1\n\nBREAKING CHANGE: use v2", combined))
+}
+
+func TestFixCommitMessage_Formats(t *testing.T) {
+	tests := []struct {
+		format  Format
+		message string
+		want    string
+	}{
+		{FormatGitmoji, "feat(auth): add login.", "✨ (auth): Add login"},
+		{FormatGitmoji, "feat!: drop v1\n\nBREAKING CHANGE: use v2", "💥 Drop v1\n\nBREAKING CHANGE: use v2"},
+		{FormatGitmoji, "✨ add login", "✨ Add login"},
+		{FormatConventionalGitmoji, "Fix(git): avoid panic", "fix(git): 🐛 avoid panic"},
+		{FormatConventionalGitmoji, "fix(git): 🐛 avoid panic", "fix(git): 🐛 avoid panic"},
+		{FormatFreeForm, "Update readme.", "Update readme"},
+		{FormatFreeForm, "Makefile: add lint target", "Makefile: add lint target"},
+		{FormatFreeForm, "Docs: explain setup", "Docs: explain setup"},
+		{FormatAngular, "Fix(core): Avoid leak", "fix(core): avoid leak"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format)+"/"+tt.message, func(t *testing.T) {
+			rules := DefaultRules().WithFormat(tt.format)
+			got := FixCommitMessage(tt.message, rules)
+			assert.Equal(t, tt.want, got)
+			assert.False(t, HasErrors(LintCommitMessage(got, rules)), LintCommitMessage(got, rules))
+		})
+	}
+
+	// Only subjects with a known type are converted.
+	assert.Equal(t, "Makefile: add lint target", FixCommitMessage("Makefile: add lint target", DefaultRules().WithFormat(FormatGitmoji)))
+}
+
+func TestCommitMessageTemplate_Format(t *testing.T) {
+	tpl := NewDefaultTemplate()
+	assert.Contains(t, tpl.GetSystemPrompt(), "Conventional Commits v1.0.0-style summary line")
+
+	tpl.SetFormat(FormatGitmoji)
+	p := tpl.GeneratePrompt("diff")
+	assert.Contains(t, tpl.GetSystemPrompt(), "a gitmoji summary line")
+	assert.Contains(t, p, "   - MUST use the gitmoji format: <gitmoji> (<scope>)?: <Description>\n")
+	assert.Contains(t, p, "✓ 🐛 (git): Avoid panic when staged diff is empty")
+	assert.NotContains(t, p, "feat(cli)")
+
+	tpl.SetFormat(FormatFreeForm)
+	p = tpl.GeneratePrompt("diff")
+	assert.Contains(t, p, "no type prefix, scope or emoji")
+	assert.NotContains(t, p, "Conventional Commits")
+}
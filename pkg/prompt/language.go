@@ -0,0 +1,145 @@
+package prompt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Language localises the generated text. The Conventional Commits type
+// keyword, scopes and trailer tokens always stay in English so that tooling
+// keeps working; only the description, body and release notes are translated.
+type Language struct {
+	// Code is the ISO 639-1 code, e.g. "de".
+	Code string
+	// Name is the English name used in the prompt rules.
+	Name string
+
+	// commit and release are instructions written in the language itself.
+	commit  string
+	release string
+	// sections are the tag message headings: Added, Changed, Fixed,
+	// Breaking Changes and Contributors.
+	sections [5]string
+}
+
+// English is the default language.
+var English = Language{
+	Code:     "en",
+	Name:     "English",
+	sections: [5]string{"Added", "Changed", "Fixed", "Breaking Changes", "Contributors"},
+}
+
+var languages = map[string]Language{
+	"en": English,
+	"zh": {
+		Code:     "zh",
+		Name:     "Simplified Chinese",
+		commit:   "请用简体中文撰写提交信息的描述和正文。类型关键字（如 feat、fix、docs）、作用域以及 BREAKING CHANGE、Refs 等尾注标记必须保持英文。",
+		release:  "请用简体中文撰写发布说明。第一行保持 \"Release <version>\" 格式不变。",
+		sections: [5]string{"新增", "变更", "修复", "破坏性变更", "贡献者"},
+	},
+	"ja": {
+		Code:     "ja",
+		Name:     "Japanese",
+		commit:   "コミットメッセージの説明と本文は日本語で書いてください。type キーワード（feat、fix、docs など）、スコープ、BREAKING CHANGE や Refs などのトレーラーは英語のままにしてください。",
+		release:  "リリースノートは日本語で書いてください。1 行目は \"Release <version>\" の形式のままにしてください。",
+		sections: [5]string{"追加", "変更", "修正", "破壊的変更", "コントリビューター"},
+	},
+	"de": {
+		Code:     "de",
+		Name:     "German",
+		commit:   "Schreibe die Beschreibung und den Text der Commit-Nachricht auf Deutsch und im Imperativ. Das Typ-Schlüsselwort (z. B. feat, fix, docs), der Scope und Trailer wie BREAKING CHANGE oder Refs bleiben auf Englisch.",
+		release:  "Schreibe die Release Notes auf Deutsch. Die erste Zeile behält das Format \"Release <version>\".",
+		sections: [5]string{"Hinzugefügt", "Geändert", "Behoben", "Inkompatible Änderungen", "Mitwirkende"},
+	},
+	"es": {
+		Code:     "es",
+		Name:     "Spanish",
+		commit:   "Escribe la descripción y el cuerpo del mensaje de commit en español y en modo imperativo. La palabra clave del tipo (p. ej. feat, fix, docs), el ámbito y los trailers como BREAKING CHANGE o Refs se mantienen en inglés.",
+		release:  "Escribe las notas de la versión en español. La primera línea mantiene el formato \"Release <version>\".",
+		sections: [5]string{"Añadido", "Cambiado", "Corregido", "Cambios incompatibles", "Colaboradores"},
+	},
+}
+
+// LookupLanguage returns the bundled language for a code such as "zh",
+// "zh-CN", "de_DE" or an English name such as "German". An empty value
+// selects English.
+func LookupLanguage(value string) (Language, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return English, nil
+	}
+	code := value
+	if i := strings.IndexAny(code, "-_."); i > 0 {
+		code = code[:i]
+	}
+	if lang, ok := languages[code]; ok {
+		return lang, nil
+	}
+	for _, lang := range languages {
+		if strings.EqualFold(lang.Name, value) || strings.HasSuffix(strings.ToLower(lang.Name), " "+value) {
+			return lang, nil
+		}
+	}
+	return Language{}, fmt.Errorf("unsupported language %q (supported: %s)", value, strings.Join(SupportedLanguages(), ", "))
+}
+
+// SupportedLanguages returns the codes of the bundled languages.
+func SupportedLanguages() []string {
+	codes := make([]string, 0, len(languages))
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// IsEnglish reports whether no translation is needed.
+func (l Language) IsEnglish() bool {
+	return l.Code == "" || l.Code == English.Code
+}
+
+func (l Language) sectionNames() [5]string {
+	if l.IsEnglish() {
+		return English.sections
+	}
+	return l.sections
+}
+
+// subjectRule is the language line of the commit subject rules; typed is set
+// for formats with a type keyword.
+func (l Language) subjectRule(typed bool) string {
+	switch {
+	case l.IsEnglish():
+		return "English, imperative mood if possible"
+	case typed:
+		return fmt.Sprintf("Description in %s, imperative mood if possible; the type keyword stays in English", l.Name)
+	}
+	return fmt.Sprintf("Written in %s, imperative mood if possible", l.Name)
+}
+
+// commitSection is appended to commit prompts for languages other than English.
+func (l Language) commitSection() string {
+	if l.IsEnglish() {
+		return ""
+	}
+	return fmt.Sprintf("LANGUAGE:\nWrite the description and body in %s. Keep the type keyword (feat, fix, ...), the scope and trailer tokens such as BREAKING CHANGE in English.\n%s", l.Name, l.commit)
+}
+
+// releaseSection is appended to tag prompts for languages other than English.
+func (l Language) releaseSection() string {
+	if l.IsEnglish() {
+		return ""
+	}
+	return fmt.Sprintf("LANGUAGE:\nWrite the release notes, including the section headings, in %s.\n%s\n", l.Name, l.release)
+}
+
+// appendSection adds section to prompt after a blank line; an empty section
+// leaves prompt unchanged.
+func appendSection(prompt, section string) string {
+	if section == "" {
+		return prompt
+	}
+	return strings.TrimRight(prompt, "\n") + "\n\n" + section
+}
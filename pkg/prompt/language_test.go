@@ -0,0 +1,77 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupLanguage(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", "en"},
+		{"zh", "zh"},
+		{"zh-CN", "zh"},
+		{"ja_JP.UTF-8", "ja"},
+		{"DE", "de"},
+		{"Spanish", "es"},
+		{"chinese", "zh"},
+	}
+	for _, tt := range tests {
+		lang, err := LookupLanguage(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, lang.Code, tt.value)
+	}
+
+	_, err := LookupLanguage("klingon")
+	assert.EqualError(t, err, `unsupported language "klingon" (supported: de, en, es, ja, zh)`)
+}
+
+func TestCommitMessageTemplate_Language(t *testing.T) {
+	tpl := NewDefaultTemplate()
+	english := tpl.GeneratePrompt("diff")
+	assert.Contains(t, english, "   - English, imperative mood if possible\n")
+	assert.NotContains(t, english, "LANGUAGE:")
+
+	lang, err := LookupLanguage("ja")
+	require.NoError(t, err)
+	tpl.SetLanguage(lang)
+	tpl.AddInstruction("A scope is required.")
+	p := tpl.GeneratePrompt("diff")
+	assert.Contains(t, p, "Description in Japanese, imperative mood if possible; the type keyword stays in English")
+	assert.Contains(t, p, "LANGUAGE:\nWrite the description and body in Japanese.")
+	assert.Contains(t, p, "日本語")
+	assert.True(t, strings.HasSuffix(p, "REPOSITORY RULES (these take precedence):\n- A scope is required."))
+}
+
+func TestTagMessageTemplate_Language(t *testing.T) {
+	lang, err := LookupLanguage("de")
+	require.NoError(t, err)
+
+	tpl := NewTagTemplate()
+	tpl.SetLanguage(lang)
+	p := tpl.GeneratePrompt("Release version: v1.2.3\n")
+	assert.Contains(t, p, "2. Language: German.")
+	assert.Contains(t, p, "   - Hinzugefügt\n   - Geändert\n   - Behoben\n   - Inkompatible Änderungen\n")
+	assert.Contains(t, p, `"Mitwirkende"`)
+	assert.Contains(t, p, "Release Notes auf Deutsch")
+	assert.NotContains(t, p, "Breaking Changes")
+	assert.NotContains(t, p, "Language: English")
+}
+
+func TestSquashMessageTemplate_Language(t *testing.T) {
+	lang, err := LookupLanguage("es")
+	require.NoError(t, err)
+
+	tpl := NewSquashTemplate()
+	assert.Contains(t, tpl.GeneratePrompt("ctx"), "   - English, imperative mood if possible, no trailing period\n")
+
+	tpl.SetLanguage(lang)
+	p := tpl.GeneratePrompt("ctx")
+	assert.Contains(t, p, "Description in Spanish")
+	assert.Contains(t, p, "en español")
+}
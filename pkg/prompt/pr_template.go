@@ -0,0 +1,118 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultPRBodyTemplate is the Markdown skeleton used for pull request bodies
+// when no custom template is configured.
+const DefaultPRBodyTemplate = `## Summary
+
+<one short paragraph: what this change does and why>
+
+## Changes
+
+- <user-facing or reviewer-relevant change>
+
+## Testing
+
+- <how the change was or should be tested>
+
+## Risk
+
+<low/medium/high and why; mention migrations, compatibility or rollout concerns>
+`
+
+// PRMessageTemplate generates prompts for pull request titles and descriptions.
+type PRMessageTemplate struct {
+	systemPrompt string
+	userPrompt   string
+	language     Language
+	format       Format
+}
+
+// NewPRTemplate creates a pull request template whose body follows bodyTemplate.
+// An empty bodyTemplate selects DefaultPRBodyTemplate.
+func NewPRTemplate(bodyTemplate string) *PRMessageTemplate {
+	bodyTemplate = strings.TrimSpace(bodyTemplate)
+	if bodyTemplate == "" {
+		bodyTemplate = strings.TrimSpace(DefaultPRBodyTemplate)
+	}
+
+	// The body template is user supplied; escape it so it survives Sprintf.
+	bodyTemplate = strings.ReplaceAll(bodyTemplate, "%", "%%")
+
+	return &PRMessageTemplate{
+		systemPrompt: `You are a senior software engineer. You write clear, accurate pull request titles and descriptions for code reviewers based strictly on the provided context.`,
+		userPrompt: `Write a pull request title and description for the branch described below.
+
+<context>
+%s
+</context>
+
+<body-template>
+` + bodyTemplate + `
+</body-template>
+
+RULES:
+1. Line 1: the pull request title only (no "Title:" prefix, no trailing period).
+   - Use %[2]s summary format: %[3]s
+   - %[4]s
+2. Line 2: blank.
+3. From line 3: the description in GitHub-flavoured Markdown following <body-template>.
+   - Keep the headings of the template; replace the placeholder text.
+   - Remove sections that do not apply only if the template marks them optional.
+4. Do not wrap the whole output in code fences.
+5. Base the content ONLY on the provided context. Do not invent changes or test results.
+`,
+	}
+}
+
+// SetFormat selects the commit message convention of the title.
+func (t *PRMessageTemplate) SetFormat(format Format) {
+	t.format = format
+}
+
+// SetLanguage selects the language of the title and description.
+func (t *PRMessageTemplate) SetLanguage(lang Language) {
+	t.language = lang
+}
+
+func (t *PRMessageTemplate) GeneratePrompt(input string) string {
+	p := fmt.Sprintf(t.userPrompt, input, t.format.title(), t.format.Syntax(), t.language.subjectRule(t.format.typed()))
+	if !t.language.IsEnglish() {
+		p = appendSection(p, fmt.Sprintf("LANGUAGE:\nWrite the title description and the pull request description in %s. Keep the type keyword, the scope, identifiers and the headings of <body-template> as they are.\n", t.language.Name))
+	}
+	return p
+}
+
+func (t *PRMessageTemplate) GetSystemPrompt() string {
+	return t.systemPrompt
+}
+
+// ParsePRMessage splits model output into a pull request title and Markdown body.
+func ParsePRMessage(text string) (title, body string, err error) {
+	text = CleanMarkdown(text)
+
+	lines := strings.Split(text, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return "", "", fmt.Errorf("pull request message is empty")
+	}
+
+	title = strings.TrimSpace(lines[0])
+	title = strings.TrimLeft(title, "# ")
+	if len(title) >= len("title:") && strings.EqualFold(title[:len("title:")], "title:") {
+		title = strings.TrimSpace(title[len("title:"):])
+	}
+	title = strings.TrimSpace(trimMatchingWrapper(title, '`'))
+	if title == "" {
+		return "", "", fmt.Errorf("pull request title is empty")
+	}
+
+	body = strings.TrimSpace(strings.Join(lines[1:], "\n"))
+	return title, body, nil
+}
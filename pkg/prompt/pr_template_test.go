@@ -0,0 +1,95 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRMessageTemplate_GeneratePrompt(t *testing.T) {
+	p := NewPRTemplate("").GeneratePrompt("Base: main\n")
+	if !containsAll(p, "<context>", "Base: main", "## Summary", "## Changes", "## Testing", "## Risk") {
+		t.Fatalf("prompt missing expected content:\n%s", p)
+	}
+
+	custom := NewPRTemplate("## Why\n\n100% of the reason\n").GeneratePrompt("ctx")
+	assert.Contains(t, custom, "## Why")
+	assert.Contains(t, custom, "100% of the reason")
+	assert.NotContains(t, custom, "## Risk")
+	assert.NotContains(t, custom, "%!")
+}
+
+func TestPRMessageTemplate_FormatAndLanguage(t *testing.T) {
+	p := NewPRTemplate("").GeneratePrompt("ctx")
+	assert.Contains(t, p, "Use Conventional Commits v1.0.0 summary format: <type>(<scope>)?!: <description>")
+	assert.NotContains(t, p, "LANGUAGE:")
+
+	tpl := NewPRTemplate("")
+	tpl.SetFormat(FormatGitmoji)
+	tpl.SetLanguage(languages["zh"])
+	p = tpl.GeneratePrompt("ctx")
+	assert.Contains(t, p, "Use gitmoji summary format: <gitmoji> (<scope>)?: <Description>")
+	assert.Contains(t, p, "Written in Simplified Chinese")
+	assert.Contains(t, p, "LANGUAGE:\nWrite the title description and the pull request description in Simplified Chinese.")
+	assert.NotContains(t, p, "%!")
+}
+
+func TestParsePRMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantTitle string
+		wantBody  string
+		wantErr   bool
+	}{
+		{
+			name:      "title and body",
+			text:      "feat(cli): add pr command\n\n## Summary\n\nAdds it.",
+			wantTitle: "feat(cli): add pr command",
+			wantBody:  "## Summary\n\nAdds it.",
+		},
+		{
+			name:      "title prefix and heading marker are stripped",
+			text:      "# Title: fix: handle empty diff\n\nBody",
+			wantTitle: "fix: handle empty diff",
+			wantBody:  "Body",
+		},
+		{
+			name:      "embedded code block is kept",
+			text:      "fix: handle empty diff\n\n## Test plan\n\n```sh\ngo test ./...\n```",
+			wantTitle: "fix: handle empty diff",
+			wantBody:  "## Test plan\n\n```sh\ngo test ./...\n```",
+		},
+		{
+			name:      "whole response fence is unwrapped",
+			text:      "```markdown\nfix: handle empty diff\n\n## Test plan\n\n```sh\ngo test ./...\n```\n```",
+			wantTitle: "fix: handle empty diff",
+			wantBody:  "## Test plan\n\n```sh\ngo test ./...\n```",
+		},
+		{
+			name:      "title only",
+			text:      "\n\nchore: bump deps",
+			wantTitle: "chore: bump deps",
+		},
+		{
+			name:    "empty",
+			text:    "  ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body, err := ParsePRMessage(tt.text)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, title)
+			assert.Equal(t, tt.wantBody, strings.TrimSpace(body))
+		})
+	}
+}
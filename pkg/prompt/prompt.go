@@ -2,18 +2,9 @@ package prompt
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 )
 
-// Conventional Commits v1.0.0 summary format:
-// <type>[optional scope][optional !]: <description>
-//
-// Notes:
-// - The spec says types are not case sensitive for implementors.
-// - We allow a conservative subset for type/scope characters to prevent malformed subjects.
-var conventionalSubjectPattern = regexp.MustCompile(`(?i)^[a-z][a-z0-9-]*(\([^\s)]+\))?(!)?: .+`)
-
 func ValidateCommitMessage(message string) error {
 	message = strings.TrimSpace(normalizeNewlines(message))
 	if message == "" {
@@ -36,20 +27,7 @@ func ValidateCommitMessage(message string) error {
 }
 
 func ValidateConventionalCommitMessage(message string) error {
-	message = strings.TrimSpace(normalizeNewlines(message))
-	if message == "" {
-		return fmt.Errorf("commit message cannot be empty")
-	}
-
-	lines := strings.Split(message, "\n")
-	subject := strings.TrimRight(lines[0], " \t")
-	if subject == "" {
-		return fmt.Errorf("commit subject cannot be empty")
-	}
-	if !conventionalSubjectPattern.MatchString(subject) {
-		return fmt.Errorf("commit subject must use Conventional Commits v1.0.0 summary format: <type>(<scope>)?!: <description>")
-	}
-	return nil
+	return FormatConventional.Validate(message)
 }
 
 func hasBody(lines []string) bool {
@@ -80,6 +58,39 @@ func CleanAIText(text string) string {
 	return strings.TrimSpace(text)
 }
 
+// CleanMarkdown trims model output that is Markdown itself. Unlike
+// CleanAIText it only unwraps a code fence that encloses the whole text, so
+// code blocks inside the text are kept.
+func CleanMarkdown(text string) string {
+	text = strings.TrimSpace(normalizeNewlines(text))
+	if inner, ok := unwrapWholeFence(text); ok {
+		text = inner
+	}
+	return strings.TrimSpace(text)
+}
+
+// unwrapWholeFence returns the content of s if s is a single fenced code
+// block. A "markdown" or "md" fence may contain further code blocks.
+func unwrapWholeFence(s string) (string, bool) {
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") {
+		return "", false
+	}
+	newline := strings.Index(s, "\n")
+	if newline == -1 || newline > len(s)-3 {
+		return "", false
+	}
+	info := strings.ToLower(strings.TrimSpace(s[3:newline]))
+	inner := s[newline+1 : len(s)-3]
+	if info != "markdown" && info != "md" {
+		for _, line := range strings.Split(inner, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				return "", false
+			}
+		}
+	}
+	return inner, true
+}
+
 func normalizeNewlines(s string) string {
 	s = strings.ReplaceAll(s, "\r\n", "\n")
 	s = strings.ReplaceAll(s, "\r", "\n")
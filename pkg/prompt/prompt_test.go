@@ -111,3 +111,38 @@ func TestCleanCommitMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestCleanMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "embedded code block is kept",
+			text:     "The commit adds a retry.\n\n```go\nfor i := 0; i < 3; i++ {\n}\n```\n\nIt fixes flaky pushes.",
+			expected: "The commit adds a retry.\n\n```go\nfor i := 0; i < 3; i++ {\n}\n```\n\nIt fixes flaky pushes.",
+		},
+		{
+			name:     "text starting and ending with code blocks is kept",
+			text:     "```sh\nmake\n```\nbuilds it, then\n```sh\nmake test\n```",
+			expected: "```sh\nmake\n```\nbuilds it, then\n```sh\nmake test\n```",
+		},
+		{
+			name:     "whole text fence is unwrapped",
+			text:     "```\n## Summary\n\nAdds a retry.\n```",
+			expected: "## Summary\n\nAdds a retry.",
+		},
+		{
+			name:     "markdown fence may contain code blocks",
+			text:     "```markdown\nAdds a retry:\n\n```go\nretry()\n```\n```",
+			expected: "Adds a retry:\n\n```go\nretry()\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CleanMarkdown(tt.text))
+		})
+	}
+}
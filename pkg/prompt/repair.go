@@ -0,0 +1,186 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FixCommitMessage applies the deterministic repairs for common rule
+// violations: it lowercases the type, strips a trailing period from the
+// subject, adjusts the case of the first letter of the description, inserts
+// the blank line after the subject and wraps body lines. Conventional Commits
+// subjects that the format rejects are converted to gitmoji when their type
+// is known. Footers are left untouched.
+func FixCommitMessage(message string, rules Rules) string {
+	message = strings.TrimSpace(normalizeNewlines(message))
+	if message == "" {
+		return message
+	}
+
+	lines := strings.Split(message, "\n")
+	subject := strings.TrimRight(lines[0], " \t")
+	if h, ok := fixHeader(subject, rules); ok {
+		subject = rules.Format.Subject(h)
+	}
+
+	rest := strings.TrimSpace(strings.Join(lines[1:], "\n"))
+	if rest == "" {
+		return subject
+	}
+
+	paragraphs := strings.Split(rest, "\n\n")
+	footers := ""
+	if _, f := splitFooters(lines[1:]); len(f) > 0 {
+		footers = paragraphs[len(paragraphs)-1]
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+
+	if rules.BodyMaxLineLength > 0 && rules.severity(RuleBodyMaxLineLength) != SeverityOff {
+		for i, p := range paragraphs {
+			paragraphs[i] = wrapParagraph(p, rules.BodyMaxLineLength)
+		}
+	}
+	if footers != "" {
+		paragraphs = append(paragraphs, footers)
+	}
+	return subject + "\n\n" + strings.Join(paragraphs, "\n\n")
+}
+
+func fixHeader(subject string, rules Rules) (Header, bool) {
+	f := rules.Format
+	h, err := f.ParseHeader(subject)
+	if err != nil {
+		// Only a subject with a header-format error is converted; a valid
+		// free-form "area: summary" subject keeps its prefix.
+		var ok bool
+		if h, ok = f.convertHeader(subject); !ok {
+			return Header{}, false
+		}
+	}
+
+	description := h.Description
+	if rules.SubjectNoPeriod && rules.severity(RuleSubjectFullStop) != SeverityOff {
+		description = strings.TrimSpace(strings.TrimRight(description, "."))
+	}
+	if rules.severity(RuleSubjectCase) != SeverityOff {
+		switch rules.SubjectCase {
+		case SubjectCaseLower:
+			description = lowerFirstWord(description)
+		case SubjectCaseSentence:
+			description = upperFirst(description)
+		}
+	}
+	if description == "" {
+		return Header{}, false
+	}
+	h.Description = description
+	h.Type = strings.ToLower(h.Type)
+	return h, true
+}
+
+// upperFirst capitalises the first letter of s.
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if !unicode.IsLower(r) {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// lowerFirstWord lowercases the first letter unless the first word looks
+// like an acronym or identifier (e.g. "JWT", "README", "GetDiff").
+func lowerFirstWord(s string) string {
+	word, _, _ := strings.Cut(s, " ")
+	first, size := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return s
+	}
+	for _, r := range word[size:] {
+		if unicode.IsUpper(r) {
+			return s
+		}
+	}
+	return string(unicode.ToLower(first)) + s[size:]
+}
+
+// wrapParagraph wraps lines longer than width at word boundaries. List items
+// keep their indentation on continuation lines; lines without spaces (e.g.
+// URLs) are left as they are.
+func wrapParagraph(p string, width int) string {
+	var out []string
+	for _, line := range strings.Split(p, "\n") {
+		if utf8.RuneCountInString(line) <= width {
+			out = append(out, line)
+			continue
+		}
+
+		prefix := leadingListPrefix(line)
+		indent := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+		words := strings.Fields(line[len(prefix):])
+
+		current := prefix
+		for _, w := range words {
+			switch {
+			case current == prefix || current == indent:
+				current += w
+			case utf8.RuneCountInString(current)+1+utf8.RuneCountInString(w) <= width:
+				current += " " + w
+			default:
+				out = append(out, current)
+				current = indent + w
+			}
+		}
+		out = append(out, current)
+	}
+	return strings.Join(out, "\n")
+}
+
+// leadingListPrefix returns the indentation and list marker of line, e.g.
+// "  - " or "1. ".
+func leadingListPrefix(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	prefix := line[:len(line)-len(trimmed)]
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(trimmed, marker) {
+			return prefix + marker
+		}
+	}
+	digits := len(trimmed) - len(strings.TrimLeft(trimmed, "0123456789"))
+	if digits > 0 && strings.HasPrefix(trimmed[digits:], ". ") {
+		return prefix + trimmed[:digits+2]
+	}
+	return prefix
+}
+
+// RepairTemplate asks the model to correct a commit message that failed
+// validation. It extends the prompt of the template that produced it.
+type RepairTemplate struct {
+	base       Template
+	previous   string
+	violations []Violation
+}
+
+// NewRepairTemplate quotes the previous message and its violations after the
+// prompt of base.
+func NewRepairTemplate(base Template, previous string, violations []Violation) *RepairTemplate {
+	return &RepairTemplate{base: base, previous: previous, violations: violations}
+}
+
+func (t *RepairTemplate) GeneratePrompt(diff string) string {
+	var b strings.Builder
+	b.WriteString(t.base.GeneratePrompt(diff))
+	b.WriteString("\n\nYour previous commit message was:\n<message>\n")
+	b.WriteString(t.previous)
+	b.WriteString("\n</message>\n\nIt was rejected because of these problems:\n")
+	for _, v := range t.violations {
+		fmt.Fprintf(&b, "- %s (%s)\n", v.Message, v.Rule)
+	}
+	b.WriteString("\nReturn ONLY the corrected commit message. Keep the content, fix every problem listed above.")
+	return b.String()
+}
+
+func (t *RepairTemplate) GetSystemPrompt() string {
+	return t.base.GetSystemPrompt()
+}
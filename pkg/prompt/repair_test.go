@@ -0,0 +1,66 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixCommitMessage(t *testing.T) {
+	rules := DefaultRules()
+	rules.SubjectCase = SubjectCaseLower
+	rules.BodyMaxLineLength = 30
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "type case and trailing period",
+			message: "Feat(API): Add login endpoint.",
+			want:    "feat(API): add login endpoint",
+		},
+		{
+			name:    "keeps acronyms",
+			message: "fix: JWT tokens expire too early",
+			want:    "fix: JWT tokens expire too early",
+		},
+		{
+			name:    "inserts blank line",
+			message: "fix: handle nil config\nConfig may be missing.",
+			want:    "fix: handle nil config\n\nConfig may be missing.",
+		},
+		{
+			name:    "wraps body but not footers",
+			message: "feat!: drop v1\n\nThe old endpoints are removed because nobody uses them.\n- a list item that is way too long to fit\nhttps://example.com/a/very/long/url/that/cannot/wrap\n\nBREAKING CHANGE: clients must migrate to the new v2 endpoints now",
+			want:    "feat!: drop v1\n\nThe old endpoints are removed\nbecause nobody uses them.\n- a list item that is way too\n  long to fit\nhttps://example.com/a/very/long/url/that/cannot/wrap\n\nBREAKING CHANGE: clients must migrate to the new v2 endpoints now",
+		},
+		{
+			name:    "non conventional subject is kept",
+			message: "Update readme.",
+			want:    "Update readme.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FixCommitMessage(tt.message, rules)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	fixed := FixCommitMessage("Fix: Handle nil config.\nbody", rules)
+	assert.Empty(t, LintCommitMessage(fixed, rules))
+}
+
+func TestRepairTemplate(t *testing.T) {
+	base := NewDefaultTemplate()
+	tpl := NewRepairTemplate(base, "Update readme", []Violation{{Rule: RuleHeaderFormat, Severity: SeverityError, Message: "bad header"}})
+
+	p := tpl.GeneratePrompt("diff --git a/README.md b/README.md")
+	assert.True(t, strings.HasPrefix(p, base.GeneratePrompt("diff --git a/README.md b/README.md")))
+	assert.Contains(t, p, "<message>\nUpdate readme\n</message>")
+	assert.Contains(t, p, "- bad header (header-format)")
+	assert.Equal(t, base.GetSystemPrompt(), tpl.GetSystemPrompt())
+}
@@ -0,0 +1,242 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReviewSeverity ranks review findings.
+type ReviewSeverity string
+
+const (
+	ReviewInfo    ReviewSeverity = "info"
+	ReviewWarning ReviewSeverity = "warning"
+	ReviewError   ReviewSeverity = "error"
+	// ReviewOff as a threshold never fails a review.
+	ReviewOff ReviewSeverity = "off"
+)
+
+// ParseReviewSeverity parses info, warning, error or off.
+func ParseReviewSeverity(value string) (ReviewSeverity, error) {
+	s := ReviewSeverity(strings.ToLower(strings.TrimSpace(value)))
+	switch s {
+	case ReviewInfo, ReviewWarning, ReviewError, ReviewOff:
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid review severity %q (expected info, warning, error or off)", value)
+}
+
+func (s ReviewSeverity) rank() int {
+	switch s {
+	case ReviewError:
+		return 3
+	case ReviewWarning:
+		return 2
+	case ReviewInfo:
+		return 1
+	}
+	return 0
+}
+
+// Finding is a problem reported by the review. Line is 0 when the finding
+// is not tied to a line, File is empty when it concerns the whole change.
+type Finding struct {
+	File     string         `json:"file"`
+	Line     int            `json:"line"`
+	Severity ReviewSeverity `json:"severity"`
+	Message  string         `json:"message"`
+}
+
+// String formats f like compiler diagnostics, "file:line: severity: message",
+// so that editors can jump to it.
+func (f Finding) String() string {
+	switch {
+	case f.File == "":
+		return fmt.Sprintf("%s: %s", f.Severity, f.Message)
+	case f.Line <= 0:
+		return fmt.Sprintf("%s: %s: %s", f.File, f.Severity, f.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", f.File, f.Line, f.Severity, f.Message)
+}
+
+// FailingFindings returns the findings at or above threshold; ReviewOff
+// never fails.
+func FailingFindings(findings []Finding, threshold ReviewSeverity) []Finding {
+	if threshold.rank() == 0 {
+		return nil
+	}
+	var failing []Finding
+	for _, f := range findings {
+		if f.Severity.rank() >= threshold.rank() {
+			failing = append(failing, f)
+		}
+	}
+	return failing
+}
+
+// ReviewSchema returns the schema of the review response.
+func ReviewSchema() JSONSchema {
+	return JSONSchema{
+		Name:        "review_findings",
+		Description: "Record the problems found in the staged changes.",
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"findings": map[string]any{
+					"type": "array",
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"file":     map[string]any{"type": "string", "description": `path from the diff header; "" for the whole change`},
+							"line":     map[string]any{"type": "integer", "description": "line in the new version of the file; 0 if unknown"},
+							"severity": map[string]any{"type": "string", "enum": []string{"info", "warning", "error"}},
+							"message":  map[string]any{"type": "string", "description": "the problem and how to fix it, in one or two sentences"},
+						},
+						"required":             []string{"file", "line", "severity", "message"},
+						"additionalProperties": false,
+					},
+				},
+			},
+			"required":             []string{"findings"},
+			"additionalProperties": false,
+		},
+	}
+}
+
+// findingLinePattern matches "file:line: severity: message" lines, which
+// models sometimes return instead of JSON.
+var findingLinePattern = regexp.MustCompile(`^\s*(?:[-*]\s+)?([^\s:][^:]*):(\d+):\s*(info|warning|error):\s*(.+)$`)
+
+// ParseReviewFindings decodes the findings of a review response: a JSON
+// object with a "findings" array, a bare JSON array, or one
+// "file:line: severity: message" line per finding. Findings are sorted by
+// severity, file and line.
+func ParseReviewFindings(text string) ([]Finding, error) {
+	text = strings.TrimSpace(text)
+	findings, err := decodeFindings(text)
+	if err != nil {
+		var ok bool
+		if findings, ok = parseFindingLines(text); !ok {
+			return nil, err
+		}
+	}
+
+	valid := findings[:0]
+	for _, f := range findings {
+		f.File = strings.TrimSpace(f.File)
+		f.Message = strings.TrimSpace(f.Message)
+		if f.Message == "" {
+			continue
+		}
+		if f.Severity, err = ParseReviewSeverity(string(f.Severity)); err != nil || f.Severity == ReviewOff {
+			f.Severity = ReviewWarning
+		}
+		valid = append(valid, f)
+	}
+
+	sort.SliceStable(valid, func(i, j int) bool {
+		a, b := valid[i], valid[j]
+		if a.Severity != b.Severity {
+			return a.Severity.rank() > b.Severity.rank()
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return valid, nil
+}
+
+func decodeFindings(text string) ([]Finding, error) {
+	start := strings.IndexAny(text, "[{")
+	if start < 0 {
+		return nil, fmt.Errorf("review response is not JSON")
+	}
+	closing := "}"
+	if text[start] == '[' {
+		closing = "]"
+	}
+	end := strings.LastIndex(text, closing)
+	if end < start {
+		return nil, fmt.Errorf("review response is not JSON")
+	}
+	data := []byte(text[start : end+1])
+
+	var response struct {
+		Findings []Finding `json:"findings"`
+	}
+	var err error
+	if closing == "]" {
+		err = json.Unmarshal(data, &response.Findings)
+	} else {
+		err = json.Unmarshal(data, &response)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode review findings: %w", err)
+	}
+	return response.Findings, nil
+}
+
+func parseFindingLines(text string) ([]Finding, bool) {
+	var findings []Finding
+	for _, line := range strings.Split(normalizeNewlines(text), "\n") {
+		m := findingLinePattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[2])
+		findings = append(findings, Finding{File: m[1], Line: n, Severity: ReviewSeverity(m[3]), Message: m[4]})
+	}
+	return findings, len(findings) > 0
+}
+
+// ReviewTemplate asks the model to review a diff before it is committed.
+type ReviewTemplate struct {
+	language Language
+}
+
+// NewReviewTemplate creates the pre-commit review template.
+func NewReviewTemplate() *ReviewTemplate {
+	return &ReviewTemplate{}
+}
+
+// SetLanguage selects the language of the finding messages.
+func (t *ReviewTemplate) SetLanguage(lang Language) {
+	t.language = lang
+}
+
+func (t *ReviewTemplate) GeneratePrompt(diff string) string {
+	p := fmt.Sprintf(`Review this diff before it is committed.
+
+<diff>
+%s
+</diff>
+
+LOOK FOR:
+- Bugs: wrong logic, off-by-one errors, nil or error handling mistakes, races
+- Security problems: secrets or credentials, injection, unsafe input handling
+- Leftovers: debug output, commented-out code, unresolved conflict markers, TODOs added by this change
+- Changes that obviously break callers, tests or documentation in the diff
+
+RULES:
+1. Report only real, actionable problems in the added or changed lines. Do not comment on style that a formatter handles, and do not praise.
+2. severity: "error" for bugs and security problems that must be fixed, "warning" for likely problems, "info" for minor suggestions.
+3. file: the path from the diff header (b/ side, without the "b/" prefix); "" if the finding concerns the whole change.
+4. line: the line number in the new version of the file, computed from the hunk headers; 0 if unsure.
+5. Return ONLY a JSON object: {"findings": [{"file": "...", "line": 0, "severity": "warning", "message": "..."}]}
+   Return {"findings": []} if there is nothing to report.
+`, diff)
+
+	if !t.language.IsEnglish() {
+		p = appendSection(p, fmt.Sprintf("LANGUAGE:\nWrite the messages in %s. Keep file names, identifiers and the severity values in English.\n", t.language.Name))
+	}
+	return p
+}
+
+func (t *ReviewTemplate) GetSystemPrompt() string {
+	return `You are a careful senior software engineer reviewing a colleague's change before it is committed. You report concrete problems precisely and briefly, and you do not invent problems that the diff does not show.`
+}
@@ -0,0 +1,67 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReviewFindings(t *testing.T) {
+	findings, err := ParseReviewFindings("```json\n" + `{"findings": [
+		{"file": "b.go", "line": 3, "severity": "warning", "message": "debug print left in"},
+		{"file": "a.go", "line": 10, "severity": "ERROR", "message": "error is ignored"},
+		{"file": "", "line": 0, "severity": "critical", "message": "no tests for the new parser"},
+		{"file": "c.go", "line": 1, "severity": "info", "message": " "}
+	]}` + "\n```")
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{File: "a.go", Line: 10, Severity: ReviewError, Message: "error is ignored"},
+		{Severity: ReviewWarning, Message: "no tests for the new parser"},
+		{File: "b.go", Line: 3, Severity: ReviewWarning, Message: "debug print left in"},
+	}, findings)
+
+	findings, err = ParseReviewFindings(`[]`)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	findings, err = ParseReviewFindings("- main.go:12: error: nil map write\nLooks good otherwise.")
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{{File: "main.go", Line: 12, Severity: ReviewError, Message: "nil map write"}}, findings)
+
+	_, err = ParseReviewFindings("Looks good to me!")
+	assert.Error(t, err)
+}
+
+func TestFinding_String(t *testing.T) {
+	assert.Equal(t, "a.go:3: error: boom", Finding{File: "a.go", Line: 3, Severity: ReviewError, Message: "boom"}.String())
+	assert.Equal(t, "a.go: info: rename", Finding{File: "a.go", Severity: ReviewInfo, Message: "rename"}.String())
+	assert.Equal(t, "warning: no tests", Finding{Severity: ReviewWarning, Message: "no tests"}.String())
+}
+
+func TestFailingFindings(t *testing.T) {
+	findings := []Finding{
+		{Severity: ReviewError, Message: "a"},
+		{Severity: ReviewWarning, Message: "b"},
+		{Severity: ReviewInfo, Message: "c"},
+	}
+	assert.Len(t, FailingFindings(findings, ReviewError), 1)
+	assert.Len(t, FailingFindings(findings, ReviewWarning), 2)
+	assert.Len(t, FailingFindings(findings, ReviewInfo), 3)
+	assert.Empty(t, FailingFindings(findings, ReviewOff))
+
+	_, err := ParseReviewSeverity("fatal")
+	assert.Error(t, err)
+}
+
+func TestReviewTemplate_GeneratePrompt(t *testing.T) {
+	template := NewReviewTemplate()
+	p := template.GeneratePrompt("diff --git a/a.go b/a.go")
+	assert.Contains(t, p, "<diff>\ndiff --git a/a.go b/a.go\n</diff>")
+	assert.Contains(t, p, `{"findings": []}`)
+	assert.NotContains(t, p, "LANGUAGE:")
+
+	template.SetLanguage(languages["ja"])
+	assert.Contains(t, template.GeneratePrompt("x"), "Write the messages in Japanese.")
+	assert.Equal(t, "review_findings", ReviewSchema().Name)
+}
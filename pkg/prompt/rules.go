@@ -0,0 +1,379 @@
+package prompt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Severity is the level of a rule violation. Errors reject the message,
+// warnings are only reported.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Rule names. They follow commitlint where an equivalent rule exists.
+const (
+	RuleHeaderFormat        = "header-format"
+	RuleHeaderMaxLength     = "header-max-length"
+	RuleTypeEnum            = "type-enum"
+	RuleTypeCase            = "type-case"
+	RuleScopeEnum           = "scope-enum"
+	RuleScopeEmpty          = "scope-empty"
+	RuleSubjectCase         = "subject-case"
+	RuleSubjectFullStop     = "subject-full-stop"
+	RuleBodyLeadingBlank    = "body-leading-blank"
+	RuleBodyMaxLineLength   = "body-max-line-length"
+	RuleTrailerRequired     = "trailer-required"
+	RuleTrailerForbidden    = "trailer-forbidden"
+	RuleBreakingConsistency = "breaking-consistency"
+)
+
+// Subject case values for Rules.SubjectCase.
+const (
+	SubjectCaseAny      = ""
+	SubjectCaseLower    = "lower"
+	SubjectCaseSentence = "sentence"
+)
+
+var defaultSeverities = map[string]Severity{
+	RuleHeaderFormat:        SeverityError,
+	RuleHeaderMaxLength:     SeverityWarning,
+	RuleTypeEnum:            SeverityError,
+	RuleTypeCase:            SeverityWarning,
+	RuleScopeEnum:           SeverityError,
+	RuleScopeEmpty:          SeverityError,
+	RuleSubjectCase:         SeverityWarning,
+	RuleSubjectFullStop:     SeverityWarning,
+	RuleBodyLeadingBlank:    SeverityError,
+	RuleBodyMaxLineLength:   SeverityWarning,
+	RuleTrailerRequired:     SeverityError,
+	RuleTrailerForbidden:    SeverityError,
+	RuleBreakingConsistency: SeverityWarning,
+}
+
+// Rules configures the commit message conventions checked by LintCommitMessage.
+// Zero values disable the corresponding rule; the header format and the blank
+// line after the subject are always checked.
+type Rules struct {
+	// Format is the commit message convention; empty means Conventional
+	// Commits. Type, scope and breaking change rules only apply to formats
+	// that have them.
+	Format Format
+	// Types and Scopes restrict the allowed values; empty allows any.
+	Types         []string
+	Scopes        []string
+	ScopeRequired bool
+	// HeaderMaxLength limits the length of the subject line in characters.
+	HeaderMaxLength int
+	// SubjectCase is SubjectCaseLower or SubjectCaseSentence for the first
+	// letter of the description.
+	SubjectCase     string
+	SubjectNoPeriod bool
+	// BodyMaxLineLength limits body lines; footers are not checked.
+	BodyMaxLineLength int
+	RequiredTrailers  []string
+	ForbiddenTrailers []string
+	// BreakingConsistency requires "!" in the subject and a BREAKING CHANGE
+	// footer to be used together.
+	BreakingConsistency bool
+	// Severities overrides the default severity of rules by name.
+	Severities map[string]Severity
+}
+
+// DefaultRules returns the rules used when nothing is configured.
+func DefaultRules() Rules {
+	return Rules{
+		HeaderMaxLength:     100,
+		SubjectNoPeriod:     true,
+		BodyMaxLineLength:   100,
+		BreakingConsistency: true,
+	}
+}
+
+// WithFormat sets the format and fills in its conventions where r leaves them
+// open: the Angular types and lower-case subjects for angular, capitalised
+// subjects for gitmoji and free-form messages.
+func (r Rules) WithFormat(f Format) Rules {
+	r.Format = f
+	switch f {
+	case FormatAngular:
+		if len(r.Types) == 0 {
+			r.Types = AngularTypes
+		}
+		if r.SubjectCase == SubjectCaseAny {
+			r.SubjectCase = SubjectCaseLower
+		}
+	case FormatGitmoji, FormatFreeForm:
+		if r.SubjectCase == SubjectCaseAny {
+			r.SubjectCase = SubjectCaseSentence
+		}
+	}
+	return r
+}
+
+// Violation is a single rule failure.
+type Violation struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s (%s)", v.Severity, v.Message, v.Rule)
+}
+
+// HasErrors reports whether any violation has error severity.
+func HasErrors(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ViolationsError returns an error describing the error-level violations, or
+// nil when there are none.
+func ViolationsError(violations []Violation) error {
+	var msgs []string
+	for _, v := range violations {
+		if v.Severity == SeverityError {
+			msgs = append(msgs, v.Message)
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "; "))
+}
+
+// IsRule reports whether name is the name of a rule, e.g. "type-enum".
+func IsRule(name string) bool {
+	_, ok := defaultSeverities[name]
+	return ok
+}
+
+// RuleNames returns the names of all rules in alphabetical order.
+func RuleNames() []string {
+	names := make([]string, 0, len(defaultSeverities))
+	for name := range defaultSeverities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r Rules) severity(rule string) Severity {
+	if s, ok := r.Severities[rule]; ok {
+		return s
+	}
+	return defaultSeverities[rule]
+}
+
+// LintCommitMessage checks message against rules and returns all violations
+// in a stable order. Rules with severity "off" are skipped.
+func LintCommitMessage(message string, rules Rules) []Violation {
+	var violations []Violation
+	report := func(rule, format string, args ...any) {
+		if sev := rules.severity(rule); sev != SeverityOff {
+			violations = append(violations, Violation{Rule: rule, Severity: sev, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	message = strings.TrimSpace(normalizeNewlines(message))
+	if message == "" {
+		report(RuleHeaderFormat, "commit message cannot be empty")
+		return violations
+	}
+
+	lines := strings.Split(message, "\n")
+	subject := strings.TrimRight(lines[0], " \t")
+
+	if hasBody(lines[1:]) && lines[1] != "" {
+		report(RuleBodyLeadingBlank, "separate subject and body with a blank line")
+	}
+	if rules.HeaderMaxLength > 0 {
+		if n := utf8.RuneCountInString(subject); n > rules.HeaderMaxLength {
+			report(RuleHeaderMaxLength, "subject is %d characters long (max %d)", n, rules.HeaderMaxLength)
+		}
+	}
+
+	h, err := rules.Format.ParseHeader(subject)
+	if err != nil {
+		report(RuleHeaderFormat, "%s", err)
+		return violations
+	}
+	description := h.Description
+
+	if rules.Format.typed() {
+		typ := strings.ToLower(h.Type)
+		if h.Type != typ {
+			report(RuleTypeCase, "type %q must be lower case", h.Type)
+		}
+		if len(rules.Types) > 0 && !containsFold(rules.Types, typ) {
+			report(RuleTypeEnum, "type %q is not allowed (allowed: %s)", typ, strings.Join(rules.Types, ", "))
+		}
+	}
+	if rules.Format.HasScope() {
+		if h.Scope == "" {
+			if rules.ScopeRequired {
+				report(RuleScopeEmpty, "scope is required")
+			}
+		} else if len(rules.Scopes) > 0 && !containsFold(rules.Scopes, h.Scope) {
+			report(RuleScopeEnum, "scope %q is not allowed (allowed: %s)", h.Scope, strings.Join(rules.Scopes, ", "))
+		}
+	}
+
+	if first, _ := utf8.DecodeRuneInString(description); unicode.IsLetter(first) {
+		switch rules.SubjectCase {
+		case SubjectCaseLower:
+			// Acronyms and identifiers such as "JWT" or "GetDiff" are fine.
+			if lowerFirstWord(description) != description {
+				report(RuleSubjectCase, "description must start with a lower-case letter")
+			}
+		case SubjectCaseSentence:
+			// Letters without case, as in Chinese or Japanese, are fine.
+			if unicode.IsLower(first) {
+				report(RuleSubjectCase, "description must start with an upper-case letter")
+			}
+		}
+	}
+	if rules.SubjectNoPeriod && strings.HasSuffix(description, ".") {
+		report(RuleSubjectFullStop, "subject must not end with a period")
+	}
+
+	body, footers := splitFooters(lines[1:])
+	if rules.BodyMaxLineLength > 0 {
+		for i, line := range strings.Split(body, "\n") {
+			if n := utf8.RuneCountInString(line); n > rules.BodyMaxLineLength {
+				report(RuleBodyMaxLineLength, "body line %d is %d characters long (max %d)", i+1, n, rules.BodyMaxLineLength)
+			}
+		}
+	}
+
+	tokens := make(map[string]bool, len(footers))
+	for _, f := range footers {
+		tokens[strings.ToLower(f.Token)] = true
+	}
+	for _, t := range rules.RequiredTrailers {
+		if !tokens[strings.ToLower(t)] {
+			report(RuleTrailerRequired, "trailer %q is required", t)
+		}
+	}
+	for _, t := range rules.ForbiddenTrailers {
+		if tokens[strings.ToLower(t)] {
+			report(RuleTrailerForbidden, "trailer %q is not allowed", t)
+		}
+	}
+
+	if rules.BreakingConsistency && rules.Format != FormatFreeForm {
+		footer := tokens["breaking change"] || tokens["breaking-change"]
+		marker, placement := `"!"`, "after the type/scope"
+		if rules.Format == FormatGitmoji {
+			marker, placement = breakingGitmoji, "as the gitmoji"
+		}
+		switch {
+		case h.Breaking && !footer:
+			report(RuleBreakingConsistency, "breaking change marked with %s needs a BREAKING CHANGE footer", marker)
+		case footer && !h.Breaking:
+			report(RuleBreakingConsistency, "BREAKING CHANGE footer needs %s %s", marker, placement)
+		}
+	}
+
+	return violations
+}
+
+// Instructions describes the configured conventions for the prompt.
+func (r Rules) Instructions() []string {
+	var out []string
+	if len(r.Types) > 0 && r.severity(RuleTypeEnum) != SeverityOff && r.Format.typed() {
+		out = append(out, fmt.Sprintf("Use only these commit types: %s.", strings.Join(r.Types, ", ")))
+	}
+	if len(r.Scopes) > 0 && r.severity(RuleScopeEnum) != SeverityOff && r.Format.HasScope() {
+		out = append(out, fmt.Sprintf("Use only these scopes: %s.", strings.Join(r.Scopes, ", ")))
+	}
+	if r.ScopeRequired && r.severity(RuleScopeEmpty) != SeverityOff && r.Format.HasScope() {
+		out = append(out, "A scope is required.")
+	}
+	if r.HeaderMaxLength > 0 && r.severity(RuleHeaderMaxLength) == SeverityError {
+		out = append(out, fmt.Sprintf("The subject line must not exceed %d characters.", r.HeaderMaxLength))
+	}
+	if r.BodyMaxLineLength > 0 && r.severity(RuleBodyMaxLineLength) == SeverityError {
+		out = append(out, fmt.Sprintf("Wrap body lines at %d characters.", r.BodyMaxLineLength))
+	}
+	if len(r.RequiredTrailers) > 0 && r.severity(RuleTrailerRequired) != SeverityOff {
+		sorted := append([]string(nil), r.RequiredTrailers...)
+		sort.Strings(sorted)
+		out = append(out, fmt.Sprintf("Always include these trailers: %s.", strings.Join(sorted, ", ")))
+	}
+	return out
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe summarises the active rules for people, e.g. as comments in the
+// commit message editor.
+func (r Rules) Describe() []string {
+	active := func(rule string) bool { return r.severity(rule) != SeverityOff }
+	scoped := r.Format.HasScope()
+
+	var out []string
+	if r.Format != "" && r.Format != FormatConventional {
+		out = append(out, fmt.Sprintf("Format: %s (%s)", r.Format, r.Format.Syntax()))
+	}
+	if len(r.Types) > 0 && active(RuleTypeEnum) && r.Format.typed() {
+		out = append(out, "Types: "+strings.Join(r.Types, ", "))
+	}
+	switch {
+	case !scoped:
+	case len(r.Scopes) > 0 && active(RuleScopeEnum):
+		scopes := "Scopes: " + strings.Join(r.Scopes, ", ")
+		if r.ScopeRequired && active(RuleScopeEmpty) {
+			scopes += " (required)"
+		}
+		out = append(out, scopes)
+	case r.ScopeRequired && active(RuleScopeEmpty):
+		out = append(out, "Scope: required")
+	}
+
+	var subject []string
+	if r.HeaderMaxLength > 0 && active(RuleHeaderMaxLength) {
+		subject = append(subject, fmt.Sprintf("at most %d characters", r.HeaderMaxLength))
+	}
+	if r.SubjectCase != SubjectCaseAny && active(RuleSubjectCase) {
+		subject = append(subject, r.SubjectCase+" case")
+	}
+	if r.SubjectNoPeriod && active(RuleSubjectFullStop) {
+		subject = append(subject, "no trailing period")
+	}
+	if len(subject) > 0 {
+		out = append(out, "Subject: "+strings.Join(subject, ", "))
+	}
+	if r.BodyMaxLineLength > 0 && active(RuleBodyMaxLineLength) {
+		out = append(out, fmt.Sprintf("Body: wrap lines at %d characters", r.BodyMaxLineLength))
+	}
+	if len(r.RequiredTrailers) > 0 && active(RuleTrailerRequired) {
+		out = append(out, "Required trailers: "+strings.Join(r.RequiredTrailers, ", "))
+	}
+	if len(r.ForbiddenTrailers) > 0 && active(RuleTrailerForbidden) {
+		out = append(out, "Forbidden trailers: "+strings.Join(r.ForbiddenTrailers, ", "))
+	}
+	if r.BreakingConsistency && active(RuleBreakingConsistency) {
+		out = append(out, `Breaking changes: "!" together with a BREAKING CHANGE footer`)
+	}
+	return out
+}
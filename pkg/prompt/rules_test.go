@@ -0,0 +1,149 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ruleNames(violations []Violation) []string {
+	var names []string
+	for _, v := range violations {
+		names = append(names, v.Rule)
+	}
+	return names
+}
+
+func TestLintCommitMessage_Defaults(t *testing.T) {
+	rules := DefaultRules()
+
+	assert.Empty(t, LintCommitMessage("feat(cli): add dry-run flag\n\nShows the message without committing.", rules))
+	assert.Empty(t, LintCommitMessage("feat(api)!: drop v1\n\nBREAKING CHANGE: use /v2", rules))
+
+	v := LintCommitMessage("Update readme", rules)
+	assert.Equal(t, []string{RuleHeaderFormat}, ruleNames(v))
+	assert.True(t, HasErrors(v))
+
+	v = LintCommitMessage("", rules)
+	assert.Equal(t, []string{RuleHeaderFormat}, ruleNames(v))
+
+	v = LintCommitMessage("Fix: handle nil config.\nbody without blank line", rules)
+	assert.Equal(t, []string{RuleBodyLeadingBlank, RuleTypeCase, RuleSubjectFullStop}, ruleNames(v))
+	assert.True(t, HasErrors(v))
+	assert.EqualError(t, ViolationsError(v), "separate subject and body with a blank line")
+
+	v = LintCommitMessage("feat!: drop flag", rules)
+	assert.Equal(t, []string{RuleBreakingConsistency}, ruleNames(v))
+	assert.False(t, HasErrors(v))
+	assert.NoError(t, ViolationsError(v))
+	assert.Equal(t, SeverityWarning, v[0].Severity)
+}
+
+func TestLintCommitMessage_ConfiguredRules(t *testing.T) {
+	rules := Rules{
+		Types:             []string{"feat", "fix"},
+		Scopes:            []string{"api", "cli"},
+		ScopeRequired:     true,
+		HeaderMaxLength:   30,
+		SubjectCase:       SubjectCaseLower,
+		BodyMaxLineLength: 20,
+		RequiredTrailers:  []string{"Refs"},
+		ForbiddenTrailers: []string{"Change-Id"},
+		Severities:        map[string]Severity{RuleHeaderMaxLength: SeverityError, RuleSubjectCase: SeverityOff},
+	}
+
+	assert.Empty(t, LintCommitMessage("fix(api): handle timeouts\n\nShort body line.\n\nRefs: PROJ-1", rules))
+
+	v := LintCommitMessage("docs(web): Update the documentation pages\n\nThis body line is definitely too long.\n\nChange-Id: I123", rules)
+	assert.Equal(t, []string{
+		RuleHeaderMaxLength,
+		RuleTypeEnum,
+		RuleScopeEnum,
+		RuleBodyMaxLineLength,
+		RuleTrailerRequired,
+		RuleTrailerForbidden,
+	}, ruleNames(v))
+	for _, violation := range v {
+		if violation.Rule == RuleBodyMaxLineLength {
+			assert.Equal(t, SeverityWarning, violation.Severity)
+		} else {
+			assert.Equal(t, SeverityError, violation.Severity, violation.Rule)
+		}
+	}
+
+	v = LintCommitMessage("feat: add thing\n\nRefs: PROJ-1", rules)
+	assert.Equal(t, []string{RuleScopeEmpty}, ruleNames(v))
+	assert.Equal(t, "error: scope is required (scope-empty)", v[0].String())
+}
+
+func TestLintCommitMessage_SubjectCase(t *testing.T) {
+	rules := DefaultRules()
+	rules.SubjectCase = SubjectCaseLower
+
+	assert.Empty(t, LintCommitMessage("fix: handle nil config", rules))
+	assert.Empty(t, LintCommitMessage("fix: JWT tokens expire too early", rules), "acronyms are kept")
+	assert.Empty(t, LintCommitMessage("refactor: GetDiff returns the truncated diff", rules), "identifiers are kept")
+	assert.Equal(t, []string{RuleSubjectCase}, ruleNames(LintCommitMessage("fix: Handle nil config", rules)))
+
+	// The fixed message passes the rule it was fixed for.
+	fixed := FixCommitMessage("fix: Handle JWT expiry", rules)
+	assert.Equal(t, "fix: handle JWT expiry", fixed)
+	assert.Empty(t, LintCommitMessage(fixed, rules))
+}
+
+func TestLintCommitMessage_SentenceCaseUncased(t *testing.T) {
+	rules := DefaultRules().WithFormat(FormatGitmoji)
+
+	assert.Empty(t, LintCommitMessage("✨ 添加登录页面", rules))
+	assert.Empty(t, LintCommitMessage("✨ Add login page", rules))
+	assert.Equal(t, []string{RuleSubjectCase}, ruleNames(LintCommitMessage("✨ add login page", rules)))
+	assert.Empty(t, LintCommitMessage("修复空配置导致的崩溃", DefaultRules().WithFormat(FormatFreeForm)))
+}
+
+func TestIsRule(t *testing.T) {
+	assert.True(t, IsRule(RuleTypeEnum))
+	assert.True(t, IsRule(RuleBreakingConsistency))
+	assert.False(t, IsRule("type-enums"))
+	assert.Len(t, RuleNames(), 13)
+	assert.Equal(t, RuleBodyLeadingBlank, RuleNames()[0])
+}
+
+func TestRules_Instructions(t *testing.T) {
+	assert.Empty(t, DefaultRules().Instructions())
+
+	rules := Rules{
+		Types:            []string{"feat", "fix"},
+		ScopeRequired:    true,
+		HeaderMaxLength:  72,
+		RequiredTrailers: []string{"Refs"},
+		Severities:       map[string]Severity{RuleHeaderMaxLength: SeverityError},
+	}
+	assert.Equal(t, []string{
+		"Use only these commit types: feat, fix.",
+		"A scope is required.",
+		"The subject line must not exceed 72 characters.",
+		"Always include these trailers: Refs.",
+	}, rules.Instructions())
+}
+
+func TestRules_Describe(t *testing.T) {
+	assert.Equal(t, []string{
+		"Subject: at most 100 characters, no trailing period",
+		"Body: wrap lines at 100 characters",
+		`Breaking changes: "!" together with a BREAKING CHANGE footer`,
+	}, DefaultRules().Describe())
+
+	rules := Rules{
+		Types:           []string{"feat", "fix"},
+		Scopes:          []string{"api"},
+		ScopeRequired:   true,
+		SubjectCase:     SubjectCaseLower,
+		HeaderMaxLength: 72,
+		Severities:      map[string]Severity{RuleHeaderMaxLength: SeverityOff},
+	}
+	assert.Equal(t, []string{
+		"Types: feat, fix",
+		"Scopes: api (required)",
+		"Subject: lower case",
+	}, rules.Describe())
+}
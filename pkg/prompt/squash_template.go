@@ -0,0 +1,58 @@
+package prompt
+
+import "fmt"
+
+// SquashMessageTemplate generates prompts for a single commit message that
+// replaces a range of commits (squash merges).
+type SquashMessageTemplate struct {
+	systemPrompt string
+	userPrompt   string
+	language     Language
+	format       Format
+}
+
+func NewSquashTemplate() *SquashMessageTemplate {
+	return &SquashMessageTemplate{
+		systemPrompt: `You are a senior software engineer. You consolidate a series of work-in-progress commits into one Git commit message that follows the gitcommit(5) guidelines and uses %s. Be concise, concrete, and accurate.`,
+		userPrompt: `Write ONE Git commit message that replaces all of the commits described below when they are squashed together.
+
+<context>
+%s
+</context>
+
+RULES:
+1. Output ONLY the commit message (no Markdown, no quotes, no code fences).
+2. Subject:
+   - MUST use %[3]s summary format: %[4]s
+   - Describe the overall change, not the individual steps taken to get there
+   - %[5]s
+   - %[2]s, no trailing period
+3. Body (only if needed):
+   - MUST be separated from the subject by a blank line
+   - Summarise what changed and why; bullet points are fine for several changes
+   - Do not list "wip", "fixup", "address review" or similar commits
+4. Footers (optional):
+   - Keep meaningful trailers from the original commits (e.g. Refs, BREAKING CHANGE, Co-authored-by)
+   - Use Git trailer style: Token: value
+5. Base the message ONLY on the provided context. Do not invent changes.
+`,
+	}
+}
+
+// SetFormat selects the commit message convention the model follows.
+func (t *SquashMessageTemplate) SetFormat(format Format) {
+	t.format = format
+}
+
+// SetLanguage selects the language of the description and body.
+func (t *SquashMessageTemplate) SetLanguage(lang Language) {
+	t.language = lang
+}
+
+func (t *SquashMessageTemplate) GeneratePrompt(input string) string {
+	return appendSection(fmt.Sprintf(t.userPrompt, input, t.language.subjectRule(t.format.typed()), t.format.title(), t.format.Syntax(), t.format.breakingRule()), t.language.commitSection())
+}
+
+func (t *SquashMessageTemplate) GetSystemPrompt() string {
+	return fmt.Sprintf(t.systemPrompt, t.format.summaryLine())
+}
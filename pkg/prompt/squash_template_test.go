@@ -0,0 +1,20 @@
+package prompt
+
+import "testing"
+
+func TestSquashMessageTemplate_GeneratePrompt(t *testing.T) {
+	tpl := NewSquashTemplate()
+	p := tpl.GeneratePrompt("Range: main..HEAD\n\nCommits:\n- wip\n")
+
+	if tpl.GetSystemPrompt() == "" {
+		t.Fatal("system prompt should not be empty")
+	}
+	if !containsAll(p,
+		"<context>",
+		"Range: main..HEAD",
+		"Conventional Commits v1.0.0",
+		"Do not list \"wip\"",
+	) {
+		t.Fatalf("prompt missing expected content:\n%s", p)
+	}
+}
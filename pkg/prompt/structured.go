@@ -0,0 +1,178 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSONSchema describes the JSON document a provider is asked to return, e.g.
+// as an OpenAI response_format, a Claude tool or an Ollama format.
+type JSONSchema struct {
+	Name        string
+	Description string
+	Schema      map[string]any
+}
+
+// StructuredCommit is a commit message returned as JSON by the model.
+type StructuredCommit struct {
+	Type     string   `json:"type"`
+	Scope    string   `json:"scope"`
+	Subject  string   `json:"subject"`
+	Body     string   `json:"body"`
+	Breaking bool     `json:"breaking"`
+	Footers  []Footer `json:"footers"`
+	// Gitmoji is only set by ParseMessage; Message derives it from the type
+	// when it is empty.
+	Gitmoji string `json:"gitmoji,omitempty"`
+}
+
+// CommitSchema returns the schema of StructuredCommit. All fields are
+// required so that it can be used with strict schema validation.
+func CommitSchema() JSONSchema {
+	str := func(description string) map[string]any {
+		return map[string]any{"type": "string", "description": description}
+	}
+	return JSONSchema{
+		Name:        "commit_message",
+		Description: "Record the parts of the Git commit message for the staged changes.",
+		Schema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"type":     str("commit type, e.g. feat, fix, docs, refactor or chore"),
+				"scope":    str(`optional scope, e.g. auth; "" for none`),
+				"subject":  str("the description only, without type, scope or emoji"),
+				"body":     str(`optional body explaining what and why; "" for none`),
+				"breaking": map[string]any{"type": "boolean", "description": "true for breaking changes"},
+				"footers": map[string]any{
+					"type":        "array",
+					"description": "trailers such as BREAKING CHANGE or Refs",
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"token": str("trailer token, e.g. BREAKING CHANGE or Refs"),
+							"value": str("trailer value"),
+						},
+						"required":             []string{"token", "value"},
+						"additionalProperties": false,
+					},
+				},
+			},
+			"required":             []string{"type", "scope", "subject", "body", "breaking", "footers"},
+			"additionalProperties": false,
+		},
+	}
+}
+
+// ParseStructuredCommit decodes the JSON object in text. Code fences and
+// text around the object are ignored.
+func ParseStructuredCommit(text string) (StructuredCommit, error) {
+	text = strings.TrimSpace(text)
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return StructuredCommit{}, fmt.Errorf("response is not a JSON object")
+	}
+
+	var c StructuredCommit
+	if err := json.Unmarshal([]byte(text[start:end+1]), &c); err != nil {
+		return StructuredCommit{}, fmt.Errorf("failed to decode structured commit message: %w", err)
+	}
+	if strings.TrimSpace(c.Subject) == "" {
+		return StructuredCommit{}, fmt.Errorf("structured commit message has no subject")
+	}
+	return c, nil
+}
+
+// Message assembles the commit message in format: the subject line, the body
+// and the footers, each separated by a blank line.
+func (c StructuredCommit) Message(format Format) string {
+	h := Header{
+		Type:        strings.ToLower(strings.TrimSpace(c.Type)),
+		Scope:       strings.TrimSpace(c.Scope),
+		Breaking:    c.Breaking,
+		Description: strings.TrimSpace(c.Subject),
+	}
+	if format == FormatGitmoji || format == FormatConventionalGitmoji {
+		if h.Gitmoji = c.Gitmoji; h.Gitmoji == "" {
+			h.Gitmoji = gitmojiFor(h)
+		}
+		if h.Gitmoji == "" {
+			h.Gitmoji = gitmojiForType["chore"]
+		}
+	}
+
+	parts := []string{format.Subject(h)}
+	if body := strings.TrimSpace(normalizeNewlines(c.Body)); body != "" {
+		parts = append(parts, body)
+	}
+	var footers []string
+	for _, f := range c.Footers {
+		token, value := strings.TrimSpace(f.Token), strings.TrimSpace(f.Value)
+		if token != "" && value != "" {
+			footers = append(footers, token+": "+value)
+		}
+	}
+	if len(footers) > 0 {
+		parts = append(parts, strings.Join(footers, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ParseMessage splits a commit message in format f into its parts. The
+// commit is breaking if the subject says so or a BREAKING CHANGE footer is
+// present.
+func (f Format) ParseMessage(message string) (StructuredCommit, error) {
+	if err := f.Validate(message); err != nil {
+		return StructuredCommit{}, err
+	}
+	lines := strings.Split(strings.TrimSpace(normalizeNewlines(message)), "\n")
+	h, err := f.ParseHeader(lines[0])
+	if err != nil {
+		return StructuredCommit{}, err
+	}
+
+	body, footers := splitFooters(lines[1:])
+	c := StructuredCommit{
+		Type:     h.Type,
+		Scope:    h.Scope,
+		Subject:  h.Description,
+		Body:     body,
+		Breaking: h.Breaking,
+		Footers:  footers,
+		Gitmoji:  h.Gitmoji,
+	}
+	for _, footer := range footers {
+		if isBreakingToken(footer.Token) {
+			c.Breaking = true
+		}
+	}
+	return c, nil
+}
+
+// StructuredTemplate asks for the commit message as a JSON object instead of
+// plain text. It extends the prompt of the template it wraps.
+type StructuredTemplate struct {
+	base Template
+}
+
+// NewStructuredTemplate wraps base, whose rules still apply to the content.
+func NewStructuredTemplate(base Template) *StructuredTemplate {
+	return &StructuredTemplate{base: base}
+}
+
+func (t *StructuredTemplate) GeneratePrompt(diff string) string {
+	return t.base.GeneratePrompt(diff) + `
+
+OUTPUT FORMAT (this replaces rule 1):
+Return ONLY a JSON object with these fields; the commit message is assembled from them:
+- type: the commit type (feat, fix, docs, refactor, ...)
+- scope: the scope, or "" for none
+- subject: the description only, without type, scope or emoji
+- body: the body, or "" if none is needed
+- breaking: true if the change breaks compatibility
+- footers: trailers as {"token": "...", "value": "..."}; describe breaking changes with the token "BREAKING CHANGE"`
+}
+
+func (t *StructuredTemplate) GetSystemPrompt() string {
+	return t.base.GetSystemPrompt()
+}
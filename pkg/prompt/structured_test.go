@@ -0,0 +1,76 @@
+package prompt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStructuredCommit(t *testing.T) {
+	c, err := ParseStructuredCommit("```json\n" + `{"type":"Feat","scope":"api","subject":"drop v1 endpoints","body":"Nobody uses them.","breaking":true,"footers":[{"token":"BREAKING CHANGE","value":"use /v2"},{"token":"Refs","value":""}]}` + "\n```")
+	require.NoError(t, err)
+	assert.Equal(t, "drop v1 endpoints", c.Subject)
+	assert.Equal(t, []Footer{{Token: "BREAKING CHANGE", Value: "use /v2"}, {Token: "Refs"}}, c.Footers)
+
+	assert.Equal(t, "feat(api)!: drop v1 endpoints\n\nNobody uses them.\n\nBREAKING CHANGE: use /v2", c.Message(FormatConventional))
+	assert.Equal(t, "💥 (api): drop v1 endpoints\n\nNobody uses them.\n\nBREAKING CHANGE: use /v2", c.Message(FormatGitmoji))
+	assert.Equal(t, "drop v1 endpoints\n\nNobody uses them.\n\nBREAKING CHANGE: use /v2", c.Message(FormatFreeForm))
+
+	c = StructuredCommit{Type: "fix", Subject: "handle nil config"}
+	assert.Equal(t, "fix: 🐛 handle nil config", c.Message(FormatConventionalGitmoji))
+	assert.Equal(t, "🔧 Update tooling", StructuredCommit{Type: "tooling", Subject: "Update tooling"}.Message(FormatGitmoji))
+
+	_, err = ParseStructuredCommit("feat: add login")
+	assert.EqualError(t, err, "response is not a JSON object")
+	_, err = ParseStructuredCommit(`{"type":"feat","subject":""}`)
+	assert.EqualError(t, err, "structured commit message has no subject")
+}
+
+func TestFormat_ParseMessage(t *testing.T) {
+	message := "feat(api): drop v1 endpoints\n\nNobody uses them.\n\nBREAKING CHANGE: use /v2\nRefs: #12"
+	c, err := FormatConventional.ParseMessage(message)
+	require.NoError(t, err)
+	assert.Equal(t, StructuredCommit{
+		Type:     "feat",
+		Scope:    "api",
+		Subject:  "drop v1 endpoints",
+		Body:     "Nobody uses them.",
+		Breaking: true,
+		Footers:  []Footer{{Token: "BREAKING CHANGE", Value: "use /v2"}, {Token: "Refs", Value: "#12"}},
+	}, c)
+
+	c, err = FormatGitmoji.ParseMessage(":sparkles: (auth): Add login")
+	require.NoError(t, err)
+	assert.Equal(t, StructuredCommit{Scope: "auth", Subject: "Add login", Gitmoji: ":sparkles:"}, c)
+	assert.Equal(t, ":sparkles: (auth): Add login", c.Message(FormatGitmoji))
+
+	_, err = FormatConventional.ParseMessage("Add login")
+	assert.Error(t, err)
+}
+
+func TestCommitSchema(t *testing.T) {
+	schema := CommitSchema()
+	assert.Equal(t, "commit_message", schema.Name)
+
+	data, err := json.Marshal(schema.Schema)
+	require.NoError(t, err)
+	var decoded struct {
+		Properties map[string]any `json:"properties"`
+		Required   []string       `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.ElementsMatch(t, []string{"type", "scope", "subject", "body", "breaking", "footers"}, decoded.Required)
+	assert.Len(t, decoded.Properties, len(decoded.Required))
+}
+
+func TestStructuredTemplate(t *testing.T) {
+	base := NewDefaultTemplate()
+	tpl := NewStructuredTemplate(base)
+
+	p := tpl.GeneratePrompt("diff")
+	assert.Contains(t, p, base.GeneratePrompt("diff"))
+	assert.Contains(t, p, "Return ONLY a JSON object")
+	assert.Equal(t, base.GetSystemPrompt(), tpl.GetSystemPrompt())
+}
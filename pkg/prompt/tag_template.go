@@ -6,6 +6,7 @@ import "fmt"
 type TagMessageTemplate struct {
 	systemPrompt string
 	userPrompt   string
+	language     Language
 }
 
 func NewTagTemplate() *TagMessageTemplate {
@@ -19,22 +20,36 @@ func NewTagTemplate() *TagMessageTemplate {
 
 RULES:
 1. Output ONLY the tag message (no Markdown, no quotes, no code fences).
-2. Language: English.
+2. Language: %[2]s.
 3. First line: "Release <version>" (use the version provided in context).
 4. Use these sections when applicable (omit empty sections):
-   - Added
-   - Changed
-   - Fixed
-   - Breaking Changes
+   - %[3]s
+   - %[4]s
+   - %[5]s
+   - %[6]s
 5. Use bullet points under each section. Keep bullets concrete and user-facing.
 6. Base the content ONLY on the provided context. Do not invent changes.
-7. If the context is insufficient, say so briefly and conservatively.
+   Use commit bodies to explain why a change matters when they are provided.
+7. When pull request numbers are listed for a commit, reference them as (#123).
+8. When contributors are listed, end with a short "%[7]s" line crediting them by name.
+9. If the context is insufficient, say so briefly and conservatively.
 `,
 	}
 }
 
+// SetLanguage selects the language of the release notes, including the
+// section headings.
+func (t *TagMessageTemplate) SetLanguage(lang Language) {
+	t.language = lang
+}
+
 func (t *TagMessageTemplate) GeneratePrompt(input string) string {
-	return fmt.Sprintf(t.userPrompt, input)
+	name := English.Name
+	if !t.language.IsEnglish() {
+		name = t.language.Name
+	}
+	s := t.language.sectionNames()
+	return appendSection(fmt.Sprintf(t.userPrompt, input, name, s[0], s[1], s[2], s[3], s[4]), t.language.releaseSection())
 }
 
 func (t *TagMessageTemplate) GetSystemPrompt() string {
@@ -19,6 +19,8 @@ func TestTagMessageTemplate_GeneratePrompt(t *testing.T) {
 		"Changed",
 		"Fixed",
 		"Breaking Changes",
+		"(#123)",
+		"Contributors",
 	) {
 		t.Fatalf("prompt missing expected content:\n%s", p)
 	}
@@ -1,6 +1,9 @@
 package prompt
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Template defines the interface for generating prompts
 type Template interface {
@@ -12,12 +15,16 @@ type Template interface {
 type CommitMessageTemplate struct {
 	systemPrompt string
 	userPrompt   string
+	instructions []string
+	examples     []string
+	language     Language
+	format       Format
 }
 
 // NewDefaultTemplate creates a new instance of the default template
 func NewDefaultTemplate() *CommitMessageTemplate {
 	return &CommitMessageTemplate{
-		systemPrompt: `You are a senior software engineer. You write Git commit messages that follow the gitcommit(5) guidelines and use a Conventional Commits v1.0.0-style summary line. Be concise, concrete, and accurate.`,
+		systemPrompt: `You are a senior software engineer. You write Git commit messages that follow the gitcommit(5) guidelines and use %s. Be concise, concrete, and accurate.`,
 		userPrompt: `Write a Git commit message for this diff. Focus on what changed and why it matters (not filenames).
 
 <diff>
@@ -32,14 +39,7 @@ RULES:
    - Optional: body (one or more lines)
    - Optional: footers (one or more trailer lines)
 3. Subject:
-   - MUST use Conventional Commits v1.0.0 summary format: <type>(<scope>)?!: <description>
-   - type MUST be a single lowercase word (do not invent new punctuation in type)
-   - scope is optional and should be short (e.g. auth, cli, git)
-   - "!" is optional and indicates a breaking change
-   - Use an optional scope when it helps (e.g. feat(auth): ...)
-   - English, imperative mood if possible
-   - No trailing period
-   - Prefer a concise, readable subject
+%[2]s
 4. Body (only if needed to explain why/impact/behavior change):
    - MUST be separated from the subject by a blank line
    - Keep lines readable when practical
@@ -60,25 +60,59 @@ ANALYZE:
 - Read the CODE CONTENT, not just filenames
 
 EXAMPLES:
-✓ feat(cli): add dry-run flag to preview generated commit message
-✓ fix(git): avoid panic when staged diff is empty
-✓ refactor(config): support XDG_CONFIG_HOME
-✓ chore(deps): update dependencies to address security advisory
-✓ feat(api)!: remove deprecated endpoint
-
-✓ feat(editor): add editor support for reviewing commit message
+%[3]s`,
+	}
+}
 
-This lets users edit the generated message before committing and reduces
-incorrect commits caused by prompt misunderstandings.`,
+// AddInstruction appends a repository-specific rule to the prompt, e.g. the
+// scopes allowed for the staged changes.
+func (t *CommitMessageTemplate) AddInstruction(instruction string) {
+	if instruction = strings.TrimSpace(instruction); instruction != "" {
+		t.instructions = append(t.instructions, instruction)
 	}
 }
 
+// SetExamples adds commit messages from the repository's history that the
+// model should imitate.
+func (t *CommitMessageTemplate) SetExamples(examples []string) {
+	t.examples = examples
+}
+
+// SetFormat selects the commit message convention the model follows.
+func (t *CommitMessageTemplate) SetFormat(format Format) {
+	t.format = format
+}
+
+// SetLanguage selects the language of the description and body.
+func (t *CommitMessageTemplate) SetLanguage(lang Language) {
+	t.language = lang
+}
+
 func (t *CommitMessageTemplate) GeneratePrompt(diff string) string {
-	return fmt.Sprintf(t.userPrompt, diff)
+	var b strings.Builder
+	b.WriteString(appendSection(fmt.Sprintf(t.userPrompt, diff, t.format.subjectRules(t.language), t.format.examples()), t.language.commitSection()))
+
+	if len(t.examples) > 0 {
+		b.WriteString("\n\nEXAMPLES FROM THIS REPOSITORY (match their tone, scopes and body style; do not copy their content):\n---\n")
+		for _, example := range t.examples {
+			b.WriteString(example)
+			b.WriteString("\n---\n")
+		}
+	}
+
+	if len(t.instructions) > 0 {
+		b.WriteString("\n\nREPOSITORY RULES (these take precedence):\n")
+		for _, instruction := range t.instructions {
+			b.WriteString("- ")
+			b.WriteString(instruction)
+			b.WriteString("\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
 }
 
 func (t *CommitMessageTemplate) GetSystemPrompt() string {
-	return t.systemPrompt
+	return fmt.Sprintf(t.systemPrompt, t.format.summaryLine())
 }
 
 var globalTemplate Template = NewDefaultTemplate()
@@ -19,3 +19,19 @@ func TestDefaultTemplateDoesNotUseHardLengthLimits(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultTemplateInstructions(t *testing.T) {
+	tpl := NewDefaultTemplate()
+	base := tpl.GeneratePrompt("diff")
+
+	tpl.AddInstruction("  ")
+	if got := tpl.GeneratePrompt("diff"); got != base {
+		t.Fatalf("blank instruction should not change the prompt")
+	}
+
+	tpl.AddInstruction("Use the scope api.")
+	got := tpl.GeneratePrompt("diff")
+	if !strings.HasPrefix(got, base) || !strings.HasSuffix(got, "REPOSITORY RULES (these take precedence):\n- Use the scope api.") {
+		t.Fatalf("unexpected prompt with instructions:\n%s", got)
+	}
+}
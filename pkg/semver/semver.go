@@ -0,0 +1,176 @@
+// Package semver parses and bumps Semantic Versioning 2.0.0 tag names.
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aicommit/aicommit/pkg/prompt"
+)
+
+// Level is the kind of version bump to apply.
+type Level string
+
+const (
+	Major Level = "major"
+	Minor Level = "minor"
+	Patch Level = "patch"
+	Pre   Level = "pre"
+)
+
+var versionPattern = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// Version is a semantic version with an optional "v" prefix.
+type Version struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease []string
+	Build      string
+}
+
+// Parse parses "1.2.3", "v1.2.3-rc.1" or "v1.2.3+build.5".
+func Parse(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not a semantic version", s)
+	}
+
+	v := Version{Prefix: m[1], Build: m[6]}
+	var err error
+	if v.Major, err = strconv.Atoi(m[2]); err != nil {
+		return Version{}, fmt.Errorf("invalid major version in %q: %w", s, err)
+	}
+	if v.Minor, err = strconv.Atoi(m[3]); err != nil {
+		return Version{}, fmt.Errorf("invalid minor version in %q: %w", s, err)
+	}
+	if v.Patch, err = strconv.Atoi(m[4]); err != nil {
+		return Version{}, fmt.Errorf("invalid patch version in %q: %w", s, err)
+	}
+	if m[5] != "" {
+		v.Prerelease = strings.Split(m[5], ".")
+	}
+	return v, nil
+}
+
+// IsPrerelease reports whether v has prerelease identifiers.
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.IsPrerelease() {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Bump returns the next version for level. Build metadata is dropped.
+//
+// Bumping a prerelease to the release it leads up to only drops the
+// prerelease identifiers, e.g. 2.0.0-rc.1 bumped by major is 2.0.0.
+// preid names the prerelease identifier used when level is Pre and v is
+// not a prerelease yet (1.2.3 -> 1.2.4-<preid>.1).
+func (v Version) Bump(level Level, preid string) (Version, error) {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+
+	switch level {
+	case Major:
+		if !v.IsPrerelease() || v.Minor != 0 || v.Patch != 0 {
+			next.Major++
+			next.Minor = 0
+			next.Patch = 0
+		}
+	case Minor:
+		if !v.IsPrerelease() || v.Patch != 0 {
+			next.Minor++
+			next.Patch = 0
+		}
+	case Patch:
+		if !v.IsPrerelease() {
+			next.Patch++
+		}
+	case Pre:
+		if v.IsPrerelease() {
+			next.Prerelease = incrementPrerelease(v.Prerelease)
+			break
+		}
+		preid = strings.TrimSpace(preid)
+		if preid == "" {
+			preid = "rc"
+		}
+		next.Patch++
+		next.Prerelease = []string{preid, "1"}
+	default:
+		return Version{}, fmt.Errorf("unknown bump level %q", level)
+	}
+
+	return next, nil
+}
+
+// incrementPrerelease bumps the last numeric identifier ("rc.1" -> "rc.2"),
+// or appends ".1" when there is none ("beta" -> "beta.1").
+func incrementPrerelease(ids []string) []string {
+	out := append([]string(nil), ids...)
+	for i := len(out) - 1; i >= 0; i-- {
+		if n, err := strconv.Atoi(out[i]); err == nil {
+			out[i] = strconv.Itoa(n + 1)
+			return out
+		}
+	}
+	return append(out, "1")
+}
+
+// LevelForCommits infers the bump level from commit messages in format:
+// breaking changes bump major, features bump minor and anything else patch.
+// Gitmoji commits count by the type their emoji stands for, so ✨ is a
+// feature and 💥 a breaking change. While the major version is 0, breaking
+// changes only bump minor.
+func LevelForCommits(current Version, format prompt.Format, messages []string) Level {
+	level := Patch
+	for _, message := range messages {
+		c, ok := parseCommit(format, message)
+		if !ok {
+			continue
+		}
+		if c.Breaking {
+			if current.Major == 0 {
+				level = Minor
+				continue
+			}
+			return Major
+		}
+		if strings.EqualFold(c.Type, "feat") {
+			level = Minor
+		}
+	}
+	return level
+}
+
+// parseCommit parses message in format. For conventional+gitmoji it also
+// accepts gitmoji-first subjects such as ":sparkles: feat: add login" and
+// plain Conventional Commits.
+func parseCommit(format prompt.Format, message string) (prompt.StructuredCommit, bool) {
+	formats := []prompt.Format{format}
+	if format == prompt.FormatConventionalGitmoji {
+		formats = append(formats, prompt.FormatGitmoji, prompt.FormatConventional)
+	}
+	for _, f := range formats {
+		c, err := f.ParseMessage(message)
+		if err != nil {
+			continue
+		}
+		if c.Type == "" {
+			c.Type = prompt.GitmojiType(c.Gitmoji)
+		}
+		return c, true
+	}
+	return prompt.StructuredCommit{}, false
+}
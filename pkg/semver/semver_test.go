@@ -0,0 +1,103 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/aicommit/aicommit/pkg/prompt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("v1.2.3-rc.1+build.7")
+	require.NoError(t, err)
+	assert.Equal(t, Version{Prefix: "v", Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"rc", "1"}, Build: "build.7"}, v)
+	assert.Equal(t, "v1.2.3-rc.1+build.7", v.String())
+
+	v, err = Parse("0.10.0")
+	require.NoError(t, err)
+	assert.Equal(t, "0.10.0", v.String())
+	assert.False(t, v.IsPrerelease())
+
+	for _, invalid := range []string{"", "1.2", "v01.2.3", "release-1", "1.2.3-"} {
+		_, err := Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestVersion_Bump(t *testing.T) {
+	tests := []struct {
+		from  string
+		level Level
+		want  string
+	}{
+		{"v1.2.3", Major, "v2.0.0"},
+		{"v1.2.3", Minor, "v1.3.0"},
+		{"v1.2.3", Patch, "v1.2.4"},
+		{"1.2.3+meta", Patch, "1.2.4"},
+		{"v1.2.3", Pre, "v1.2.4-rc.1"},
+		{"v2.0.0-rc.1", Pre, "v2.0.0-rc.2"},
+		{"v2.0.0-beta", Pre, "v2.0.0-beta.1"},
+		{"v2.0.0-rc.1", Major, "v2.0.0"},
+		{"v2.1.0-rc.1", Major, "v3.0.0"},
+		{"v1.3.0-rc.1", Minor, "v1.3.0"},
+		{"v1.3.1-rc.1", Minor, "v1.4.0"},
+		{"v1.3.1-rc.1", Patch, "v1.3.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"/"+string(tt.level), func(t *testing.T) {
+			v, err := Parse(tt.from)
+			require.NoError(t, err)
+			next, err := v.Bump(tt.level, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, next.String())
+		})
+	}
+
+	v, _ := Parse("1.0.0")
+	next, err := v.Bump(Pre, "beta")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1-beta.1", next.String())
+
+	_, err = v.Bump("huge", "")
+	assert.Error(t, err)
+}
+
+func TestLevelForCommits(t *testing.T) {
+	v1, _ := Parse("v1.4.0")
+	v0, _ := Parse("v0.4.0")
+
+	conventional := prompt.FormatConventional
+	assert.Equal(t, Patch, LevelForCommits(v1, conventional, []string{"fix: a", "docs: b", "not conventional"}))
+	assert.Equal(t, Minor, LevelForCommits(v1, conventional, []string{"fix: a", "feat(cli): b"}))
+	assert.Equal(t, Major, LevelForCommits(v1, conventional, []string{"feat: a", "fix!: b"}))
+	assert.Equal(t, Major, LevelForCommits(v1, conventional, []string{"chore: a\n\nBREAKING CHANGE: config moved"}))
+	assert.Equal(t, Minor, LevelForCommits(v0, conventional, []string{"fix!: b"}))
+	assert.Equal(t, Patch, LevelForCommits(v1, conventional, nil))
+}
+
+func TestLevelForCommits_Gitmoji(t *testing.T) {
+	v1, _ := Parse("v1.4.0")
+
+	tests := []struct {
+		name     string
+		format   prompt.Format
+		messages []string
+		want     Level
+	}{
+		{"gitmoji fix", prompt.FormatGitmoji, []string{":bug: Fix the URL parser"}, Patch},
+		{"gitmoji feature", prompt.FormatGitmoji, []string{":bug: Fix a crash", ":sparkles: Add login page"}, Minor},
+		{"gitmoji emoji feature", prompt.FormatGitmoji, []string{"✨ (auth): Add login page"}, Minor},
+		{"gitmoji breaking", prompt.FormatGitmoji, []string{"💥 Drop the v1 API"}, Major},
+		{"conventional gitmoji", prompt.FormatConventionalGitmoji, []string{"feat(auth): ✨ add login"}, Minor},
+		{"gitmoji first feature", prompt.FormatConventionalGitmoji, []string{":sparkles: feat: add login"}, Minor},
+		{"gitmoji first breaking", prompt.FormatConventionalGitmoji, []string{":boom: feat!: drop v1 API"}, Major},
+		{"plain conventional", prompt.FormatConventionalGitmoji, []string{"feat: add login"}, Minor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, LevelForCommits(v1, tt.format, tt.messages))
+		})
+	}
+}